@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/nixknight/binarius/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish>",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for bash, zsh, or fish.
+
+Completion covers commands and flags, tool names, installed versions for
+'use', 'uninstall', and 'verify', and available versions for 'install'. Available versions are fetched from the release API and cached
+for an hour in <cache-dir>/versions.
+
+Load completions in the current shell:
+  source <(binarius completion bash)
+  source <(binarius completion zsh)
+  binarius completion fish | source
+
+Load completions for every new shell:
+  binarius completion bash > ~/.local/share/bash-completion/completions/binarius
+  binarius completion zsh > "${fpath[1]}/_binarius"
+  binarius completion fish > ~/.config/fish/completions/binarius.fish`,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	DisableFlagsInUseLine: true,
+	// Generating a script doesn't need config.yaml
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return applyGlobalFlags() },
+	RunE:              runCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	default: // fish; Args rejects anything else
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	}
+}
+
+// versionSource returns the versions to complete for a tool.
+type versionSource func(cfg *config.Config, toolName string) []string
+
+// completeToolVersion completes the <tool>@<version> argument of a command
+// that takes one: first tool names followed by '@', then the versions from
+// source.
+func completeToolVersion(source versionSource) cobra.CompletionFunc {
+	complete := completeToolVersions(source)
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeToolVersions completes any number of <tool>@<version> arguments,
+// like completeToolVersion.
+func completeToolVersions(source versionSource) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cfg, ok := completionConfig()
+		if !ok {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		toolName, _, found := strings.Cut(toComplete, "@")
+		if !found {
+			var completions []string
+			for _, name := range completionToolNames(cfg) {
+				completions = append(completions, name+"@")
+			}
+			return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []string
+		for _, version := range source(cfg, toolName) {
+			completions = append(completions, toolName+"@"+version)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	}
+}
+
+// completeToolName completes a tool name argument.
+func completeToolName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, ok := completionConfig()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return completionToolNames(cfg), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeToolNames completes any number of tool name arguments.
+func completeToolNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, ok := completionConfig()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var names []string
+	for _, name := range completionToolNames(cfg) {
+		if !slices.Contains(args, name) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionConfig applies the global flags and loads config.yaml, which
+// cobra doesn't do before calling completion functions.
+func completionConfig() (*config.Config, bool) {
+	if err := applyGlobalFlags(); err != nil {
+		return nil, false
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, false
+	}
+	return cfg, true
+}
+
+// completionToolNames returns the supported tools the system config allows.
+func completionToolNames(cfg *config.Config) []string {
+	var names []string
+	for _, name := range tools.List() {
+		if cfg.IsToolAllowed(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// installedVersions returns the installed versions of a tool.
+func installedVersions(_ *config.Config, toolName string) []string {
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return nil
+	}
+	registry, err := config.LoadRegistry(registryPath)
+	if err != nil {
+		return nil
+	}
+
+	versions := registry.ListVersions(toolName)
+	sort.Strings(versions)
+	return versions
+}
+
+// availableVersions returns "latest" and the versions the tool's release API
+// (or mirror) offers, newest first, using the version cache.
+func availableVersions(cfg *config.Config, toolName string) []string {
+	tool, err := resolveTool(cfg, toolName)
+	if err != nil {
+		return nil
+	}
+
+	cache, err := versionCache()
+	if err != nil {
+		return nil
+	}
+	versions, err := cache.ListVersions(tool)
+	if err != nil {
+		return []string{"latest"}
+	}
+
+	return append([]string{"latest"}, versions...)
+}
+
+// versionCache returns the cache of available versions in <cache-dir>/versions.
+func versionCache() (*tools.VersionCache, error) {
+	cacheDir, err := paths.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &tools.VersionCache{Dir: filepath.Join(cacheDir, "versions")}, nil
+}
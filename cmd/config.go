@@ -0,0 +1,435 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configListShowOrigin bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and edit config.yaml settings",
+	Long: `Read and edit settings in config.yaml by key.
+
+Settings from the system config (/etc/binarius/config.yaml, or
+$BINARIUS_SYSTEM_CONFIG) apply first and your config.yaml overrides them,
+except for keys the system config lists under 'locked'. get and list show the
+effective settings; set, unset and edit change your config.yaml only.
+
+Keys:
+` + settingsHelp() + `
+Examples:
+  binarius config list
+  binarius config list --show-origin
+  binarius config get mirrors.default
+  binarius config set verification.tools.terraform strict
+  binarius config set gpg.key_files ~/keys/hashicorp-2025.asc,~/keys/internal.asc
+  binarius config unset mirrors.tools.tofu
+  binarius config edit
+  binarius config validate`,
+	// Don't fail up front on a broken config.yaml; validate and edit exist to deal with it
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return applyGlobalFlags() },
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a setting",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUnset,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print every setting that has a value",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open config.yaml in $VISUAL or $EDITOR and validate it before saving",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigEdit,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check config.yaml for unknown keys and invalid values",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigValidate,
+}
+
+func init() {
+	configListCmd.Flags().BoolVar(&configListShowOrigin, "show-origin", false, "Show the file each value comes from")
+	configCmd.AddCommand(configGetCmd, configSetCmd, configUnsetCmd, configListCmd, configEditCmd, configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// settingsHelp lists the settable keys for the config command's help text.
+func settingsHelp() string {
+	var b strings.Builder
+	for _, setting := range config.Settings() {
+		fmt.Fprintf(&b, "  %-28s %-8s %s\n", setting.Key, setting.Type, setting.Description)
+	}
+	return b.String()
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	value, ok, err := cfg.Get(args[0])
+	if err != nil {
+		return unknownKeyError(err)
+	}
+	if !ok {
+		return utils.NewUserError(
+			fmt.Sprintf("%s is not set", args[0]),
+			"config.yaml has no value for this key",
+			fmt.Sprintf("Set it with 'binarius config set %s <value>'", args[0]),
+		).WithCode(utils.CodeNotFound)
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return err
+	}
+	if layers.IsLocked(key) {
+		return utils.NewUserError(
+			fmt.Sprintf("%s is locked", key),
+			fmt.Sprintf("The system config %s doesn't allow overriding it", layers.SystemPath),
+			"Ask your administrator to change the setting",
+		).WithCode(utils.CodeNotAllowed)
+	}
+
+	return updateConfig(func(cfg *config.Config) error {
+		if err := cfg.Set(key, value); err != nil {
+			if _, _, lookupErr := config.LookupSetting(key); lookupErr != nil {
+				return unknownKeyError(lookupErr)
+			}
+			return utils.NewUserError(
+				fmt.Sprintf("Failed to set %s", key),
+				err.Error(),
+				"Run 'binarius config --help' to see the type of each key",
+			).WithCode(utils.CodeUsage)
+		}
+
+		newValue, _, _ := cfg.Get(key)
+		messagef("✓ Set %s = %s\n", key, newValue)
+		return nil
+	})
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	return updateConfig(func(cfg *config.Config) error {
+		wasSet, err := cfg.Unset(key)
+		if err != nil {
+			return unknownKeyError(err)
+		}
+
+		if wasSet {
+			messagef("✓ Unset %s\n", key)
+		} else {
+			messagef("%s was not set\n", key)
+		}
+		return nil
+	})
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range layers.Merged().List() {
+		if configListShowOrigin {
+			fmt.Printf("file:%s\t", layers.Origin(kv.Key))
+		}
+		fmt.Printf("%s=%s\n", kv.Key, kv.Value)
+	}
+
+	for _, kv := range layers.Ignored() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s=%s in %s is ignored: locked by %s\n", kv.Key, kv.Value, layers.UserPath, layers.SystemPath)
+	}
+	return nil
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	systemPath, err := paths.SystemConfigFile()
+	if err != nil {
+		return err
+	}
+
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, path := range []string{systemPath, configPath} {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		found = true
+
+		if err := config.ValidateFile(path); err != nil {
+			if config.IsNewerSchema(err) {
+				return newerSchemaError("config.yaml", err)
+			}
+			return invalidConfigError(path, err)
+		}
+		messagef("✓ %s is valid\n", path)
+	}
+
+	if !found {
+		messagef("%s doesn't exist; defaults are in effect\n", configPath)
+		return nil
+	}
+
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return err
+	}
+	for _, kv := range layers.Ignored() {
+		noticef("⚠️  %s is ignored: locked by %s\n", kv.Key, systemPath)
+	}
+
+	return nil
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	original, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return utils.NewUserError(
+			"Failed to read config.yaml",
+			err.Error(),
+			fmt.Sprintf("Ensure %s is readable", configPath),
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	content := original
+	if os.IsNotExist(err) {
+		if content, err = yaml.Marshal(config.New()); err != nil {
+			return err
+		}
+	}
+
+	// Edit a copy so an invalid or abandoned edit never reaches config.yaml
+	tmpFile, err := os.CreateTemp("", "binarius-config-*.yaml")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	_, writeErr := tmpFile.Write(content)
+	closeErr := tmpFile.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %v %v", tmpPath, writeErr, closeErr)
+	}
+
+	var edited []byte
+	for {
+		if err := runEditor(tmpPath); err != nil {
+			return utils.NewUserError(
+				"Failed to run editor",
+				err.Error(),
+				fmt.Sprintf("Set $VISUAL or $EDITOR to your editor; your edits are in %s", tmpPath),
+			)
+		}
+
+		if edited, err = os.ReadFile(tmpPath); err != nil {
+			return err
+		}
+
+		if bytes.Equal(edited, content) {
+			_ = os.Remove(tmpPath)
+			messagef("No changes made\n")
+			return nil
+		}
+
+		validateErr := config.ValidateData(configPath, edited)
+		if validateErr == nil {
+			break
+		}
+
+		fmt.Fprintf(os.Stderr, "✗ The edited config is invalid:\n%s\n", indent(validateErr.Error()))
+		if !confirm("Edit again? [Y/n] ", true) {
+			return utils.NewUserError(
+				"config.yaml was not changed",
+				"The edited config is invalid",
+				fmt.Sprintf("Your edits are in %s", tmpPath),
+			).WithCode(utils.CodeConfig)
+		}
+	}
+
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	// Refuse to overwrite changes made by another command while the editor was open
+	current, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !bytes.Equal(current, original) {
+		return utils.NewUserError(
+			"config.yaml changed while you were editing it",
+			"Saving would discard the other changes",
+			fmt.Sprintf("Merge your edits from %s into %s", tmpPath, configPath),
+		).WithCode(utils.CodeConfig)
+	}
+
+	if err := config.SaveData(edited, configPath); err != nil {
+		return utils.NewUserError(
+			"Failed to save config.yaml",
+			err.Error(),
+			fmt.Sprintf("Your edits are in %s", tmpPath),
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	_ = os.Remove(tmpPath)
+	messagef("✓ Saved %s\n", configPath)
+	return nil
+}
+
+// updateConfig applies a change to config.yaml under the home lock, refusing
+// to touch a file that can't be parsed.
+func updateConfig(change func(cfg *config.Config) error) error {
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	cfg, err := loadConfigForUpdate()
+	if err != nil {
+		return err
+	}
+
+	if err := change(cfg); err != nil {
+		return err
+	}
+
+	if err := config.Save(cfg, configPath); err != nil {
+		return utils.NewUserError(
+			"Failed to save config.yaml",
+			err.Error(),
+			fmt.Sprintf("Ensure %s is writable", configPath),
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		noticef("⚠️  config.yaml has other problems:\n%s\n", indent(err.Error()))
+	}
+
+	return nil
+}
+
+// unknownKeyError explains a key that isn't a known setting.
+func unknownKeyError(err error) error {
+	return utils.NewUserError(
+		"Unknown config key",
+		err.Error(),
+		"Run 'binarius config --help' to see the available keys",
+	).WithCode(utils.CodeUsage)
+}
+
+// invalidConfigError reports the problems found in config.yaml.
+func invalidConfigError(configPath string, err error) error {
+	return utils.NewUserError(
+		fmt.Sprintf("%s is invalid", configPath),
+		err.Error(),
+		"Fix it with 'binarius config edit' or 'binarius config set'",
+	).WithCode(utils.CodeConfig)
+}
+
+// runEditor opens path in $VISUAL, $EDITOR, or vi.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Editors are often configured with arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	editorCmd := exec.Command(fields[0], append(fields[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	return editorCmd.Run()
+}
+
+// confirm asks a yes/no question on stdin. An empty answer returns def.
+func confirm(prompt string, def bool) bool {
+	fmt.Fprint(os.Stderr, prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return def
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// indent prefixes every line of s with two spaces.
+func indent(s string) string {
+	return "  " + strings.ReplaceAll(s, "\n", "\n  ")
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/doctor"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems with the Binarius setup",
+	Long: `Check the Binarius environment for common problems.
+
+Checks:
+  - config.yaml and installation.json can be parsed
+  - Binarius directories are writable
+  - The bin directory is in PATH and not shadowed by other installs
+  - Symlinks point at installed versions
+  - Registered versions still have their binaries
+  - Tools directory has no versions missing from the registry
+
+Example:
+  binarius doctor`,
+	Args: cobra.NoArgs,
+	// Skip loading config.yaml up front; a broken config is one of the things doctor reports
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return applyGlobalFlags() },
+	RunE:              runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	var findings []doctor.Finding
+
+	systemPath, err := paths.SystemConfigFile()
+	if err != nil {
+		return err
+	}
+
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	layers, err := config.LoadLayers(systemPath, configPath)
+	if config.IsNewerSchema(err) {
+		findings = append(findings, newerSchemaFinding("config.yaml", err))
+	} else if err != nil {
+		findings = append(findings, doctor.Finding{
+			Severity: doctor.Error,
+			Problem: utils.NewUserError(
+				"config.yaml can't be parsed",
+				err.Error(),
+				"Fix the syntax errors in the file named above",
+			),
+		})
+	} else {
+		applyConfigPaths(layers.Merged())
+
+		for _, kv := range layers.Ignored() {
+			findings = append(findings, doctor.Finding{
+				Severity: doctor.Warning,
+				Problem: utils.NewUserError(
+					fmt.Sprintf("%s in %s has no effect", kv.Key, configPath),
+					fmt.Sprintf("The key is locked by %s", systemPath),
+					fmt.Sprintf("Remove it with 'binarius config unset %s'", kv.Key),
+				),
+			})
+		}
+	}
+
+	binariusHome, err := paths.BinariusHome()
+	if err != nil {
+		return err
+	}
+
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return err
+	}
+
+	cacheDir, err := paths.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	toolsDir, err := paths.ToolsDir()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(binariusHome); os.IsNotExist(err) {
+		return utils.NewUserError(
+			"Binarius is not initialized",
+			fmt.Sprintf("%s does not exist", binariusHome),
+			"Run 'binarius init' to initialize Binarius",
+		).WithCode(utils.CodeNotInstalled)
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+
+	registry, err := config.LoadRegistry(registryPath)
+	if config.IsNewerSchema(err) {
+		findings = append(findings, newerSchemaFinding("installation.json", err))
+	} else if err != nil {
+		findings = append(findings, doctor.Finding{
+			Severity: doctor.Error,
+			Problem: utils.NewUserError(
+				"installation.json can't be parsed",
+				err.Error(),
+				fmt.Sprintf("Restore %s from a backup, or fix its JSON syntax", registryPath),
+			),
+		})
+	}
+
+	findings = append(findings, doctor.CheckWritable(binariusHome, binDir, cacheDir, toolsDir)...)
+
+	var toolNames []string
+	if registry != nil {
+		toolNames = registry.ListTools()
+	}
+	findings = append(findings, doctor.CheckPath(binDir, os.Getenv("PATH"), toolNames)...)
+	findings = append(findings, doctor.CheckSymlinks(binDir, toolsDir)...)
+
+	if registry != nil {
+		findings = append(findings, doctor.CheckRegistry(registry)...)
+		findings = append(findings, doctor.CheckOrphans(toolsDir, registry)...)
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("✓ No problems found")
+		return nil
+	}
+
+	errorCount := 0
+	for _, finding := range findings {
+		marker := "⚠️ "
+		if finding.Severity == doctor.Error {
+			marker = "✗"
+			errorCount++
+		}
+
+		fmt.Printf("%s %s\n", marker, finding.Problem.Context)
+		fmt.Printf("  Reason: %s\n", finding.Problem.Reason)
+		fmt.Printf("  Action: %s\n\n", finding.Problem.Action)
+	}
+
+	fmt.Printf("Found %d error(s) and %d warning(s)\n", errorCount, len(findings)-errorCount)
+
+	if errorCount > 0 {
+		return utils.NewUserError(
+			"Binarius setup has problems",
+			fmt.Sprintf("%d error(s) found", errorCount),
+			"Follow the actions above, then run 'binarius doctor' again",
+		)
+	}
+
+	return nil
+}
+
+// newerSchemaFinding reports a file written by a newer Binarius, which this one can't check.
+func newerSchemaFinding(file string, err error) doctor.Finding {
+	return doctor.Finding{Severity: doctor.Error, Problem: newerSchemaError(file, err)}
+}
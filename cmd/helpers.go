@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/httpclient"
+	"github.com/nixknight/binarius/pkg/lock"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/nixknight/binarius/pkg/tools"
+)
+
+// loadConfig loads the effective configuration, the system config.yaml with
+// the user's config.yaml on top, and applies its directory paths to pkg/paths.
+// Missing files contribute nothing.
+func loadConfig() (*config.Config, error) {
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := layers.Merged()
+	applyConfigPaths(cfg)
+	return cfg, nil
+}
+
+// loadConfigLayers loads the system and user config.yaml files.
+func loadConfigLayers() (*config.Layers, error) {
+	systemPath, err := paths.SystemConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := config.LoadLayers(systemPath, configPath)
+	if err != nil {
+		return nil, configLoadError(err)
+	}
+	return layers, nil
+}
+
+// loadConfigForUpdate loads only the user's config.yaml, for commands that
+// change and save it. Unlike loadConfig, it refuses a file with unknown keys,
+// so a misspelled key is reported instead of silently dropped on save.
+// If the file doesn't exist yet, an empty configuration is returned.
+func loadConfigForUpdate() (*config.Config, error) {
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return config.New(), nil
+	}
+
+	cfg, err := config.LoadStrict(configPath)
+	if err != nil {
+		return nil, configLoadError(err)
+	}
+	return cfg, nil
+}
+
+// configLoadError explains why a config.yaml couldn't be loaded.
+func configLoadError(err error) error {
+	if config.IsNewerSchema(err) {
+		return newerSchemaError("config.yaml", err)
+	}
+	return utils.NewUserError(
+		"Failed to load config.yaml",
+		err.Error(),
+		"Fix the errors in the file named above; 'binarius config edit' edits your own config.yaml",
+	).WithCode(utils.CodeConfig)
+}
+
+// applyConfigPaths hands the directories configured in config.yaml to pkg/paths.
+func applyConfigPaths(cfg *config.Config) {
+	paths.SetConfig(paths.Overrides{
+		Home:     cfg.Paths.BinariusHome,
+		BinDir:   cfg.Paths.BinDir,
+		CacheDir: cfg.Paths.CacheDir,
+	})
+}
+
+// loadRegistry loads installation.json, migrating it if it was written by an older Binarius.
+func loadRegistry(registryPath string) (*config.Registry, error) {
+	registry, err := config.LoadRegistry(registryPath)
+	if err != nil {
+		if config.IsNewerSchema(err) {
+			return nil, newerSchemaError("installation.json", err)
+		}
+		return nil, utils.NewUserError(
+			"Failed to load installation registry",
+			err.Error(),
+			"Run 'binarius init' to initialize Binarius",
+		).WithCode(utils.CodeConfig)
+	}
+
+	return registry, nil
+}
+
+// newerSchemaError explains that a file was written by a newer Binarius than the one running.
+func newerSchemaError(file string, err error) *utils.UserError {
+	return utils.NewUserError(
+		fmt.Sprintf("%s was written by a newer version of Binarius", file),
+		err.Error(),
+		"Upgrade Binarius to the version that wrote it, or restore the .bak-v<N> backup it left next to the file",
+	).WithCode(utils.CodeConfig)
+}
+
+// resolveTool looks up a registered tool and routes it through the mirror
+// configured for it in config.yaml, if any.
+func resolveTool(cfg *config.Config, toolName string) (tools.Tool, error) {
+	if err := checkToolAllowed(cfg, toolName); err != nil {
+		return nil, err
+	}
+
+	tool, err := tools.Get(toolName)
+	if err != nil {
+		return nil, utils.NewUserError(
+			fmt.Sprintf("Tool '%s' is not supported", toolName),
+			err.Error(),
+			fmt.Sprintf("Supported tools: %s", strings.Join(tools.List(), ", ")),
+		).WithCode(utils.CodeNotFound)
+	}
+
+	mirror := tools.Mirror{Base: cfg.GetMirror(toolName), Hosts: cfg.GetHostMirrors()}
+	if mirror.IsZero() {
+		return tool, nil
+	}
+
+	bases := []string{mirror.Base}
+	for _, base := range mirror.Hosts {
+		bases = append(bases, base)
+	}
+	for _, base := range bases {
+		if base == "" {
+			continue
+		}
+		if err := tools.ValidateMirror(base); err != nil {
+			return nil, utils.NewUserError(
+				fmt.Sprintf("Invalid mirror configured for %s", toolName),
+				err.Error(),
+				"Set a base URL like 'https://artifactory.example.com/hashicorp' under 'mirrors' in config.yaml",
+			).WithCode(utils.CodeConfig)
+		}
+	}
+
+	return tools.WithMirror(tool, mirror), nil
+}
+
+// checkToolAllowed refuses tools that tools.allowed in config.yaml doesn't list.
+func checkToolAllowed(cfg *config.Config, toolName string) error {
+	if cfg.IsToolAllowed(toolName) {
+		return nil
+	}
+	return utils.NewUserError(
+		fmt.Sprintf("%s is not allowed", toolName),
+		fmt.Sprintf("tools.allowed in config.yaml only permits: %s", strings.Join(cfg.Tools.Allowed, ", ")),
+		"Ask your administrator to allow it, or use one of the permitted tools",
+	).WithCode(utils.CodeNotAllowed)
+}
+
+// verificationPolicy returns the verification policy configured for a tool in config.yaml.
+func verificationPolicy(cfg *config.Config, toolName string) (config.Policy, error) {
+	policy, err := config.ParsePolicy(cfg.GetVerification(toolName))
+	if err != nil {
+		return "", utils.NewUserError(
+			fmt.Sprintf("Invalid verification policy configured for %s", toolName),
+			err.Error(),
+			"Set 'verification' in config.yaml to strict, checksum, or none",
+		).WithCode(utils.CodeConfig)
+	}
+
+	return policy, nil
+}
+
+// trustedKeys returns the signing keys bundled with a tool plus any additional
+// keys listed under 'gpg.key_files' in config.yaml.
+func trustedKeys(cfg *config.Config, tool tools.GPGSigned) ([][]byte, error) {
+	keys := tool.SigningKeys()
+
+	for _, keyFile := range cfg.GPG.KeyFiles {
+		expanded, err := paths.Expand(keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := os.ReadFile(expanded)
+		if err != nil {
+			return nil, utils.NewUserError(
+				fmt.Sprintf("Failed to read GPG key file: %s", keyFile),
+				err.Error(),
+				"Check the 'gpg.key_files' entries in config.yaml",
+			).WithCode(utils.CodeConfig)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// configureHTTP applies the TLS settings from config.yaml to the shared HTTP client.
+// Proxy settings come from the HTTPS_PROXY and NO_PROXY environment variables.
+func configureHTTP(cfg *config.Config) error {
+	caBundle, err := paths.Expand(cfg.TLS.CABundle)
+	if err != nil {
+		return err
+	}
+
+	clientCert, err := paths.Expand(cfg.TLS.ClientCert)
+	if err != nil {
+		return err
+	}
+
+	clientKey, err := paths.Expand(cfg.TLS.ClientKey)
+	if err != nil {
+		return err
+	}
+
+	opts := httpclient.Options{
+		CABundle:   caBundle,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	}
+
+	if err := httpclient.Configure(opts); err != nil {
+		return utils.NewUserError(
+			"Invalid TLS configuration",
+			err.Error(),
+			"Check the 'tls' section of config.yaml (ca_bundle, client_cert, client_key)",
+		).WithCode(utils.CodeConfig)
+	}
+
+	return nil
+}
+
+// lockTimeout is how long a command waits for another Binarius process to
+// release the lock on the Binarius home directory.
+const lockTimeout = 2 * time.Minute
+
+// lockHome takes the cross-process lock on the Binarius home directory.
+// It must be held around every read-modify-write of installation.json and config.yaml.
+func lockHome() (*lock.Lock, error) {
+	binariusHome, err := paths.BinariusHome()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := lock.Acquire(binariusHome, lockTimeout, func(pid int) {
+		if pid > 0 {
+			noticef("Waiting for lock held by PID %d...\n", pid)
+		} else {
+			noticef("Waiting for another Binarius process to finish...\n")
+		}
+	})
+	if errors.Is(err, lock.ErrTimeout) {
+		// The kernel releases the lock when its holder exits, so the lock file
+		// never needs removing; removing it would let two processes lock
+		// different files while the holder still runs
+		action := "Wait for the other Binarius process to finish, or stop it if it is stuck"
+		if pid := lock.HolderPID(binariusHome); pid > 0 {
+			action = fmt.Sprintf("Wait for process %d to finish, or stop it if it is stuck (e.g. 'kill %d')", pid, pid)
+		}
+		return nil, utils.NewUserError(
+			"Another Binarius process is still running",
+			err.Error(),
+			action,
+		).WithCode(utils.CodeLocked)
+	}
+	if err != nil {
+		return nil, utils.NewUserError(
+			"Failed to lock the Binarius home directory",
+			err.Error(),
+			fmt.Sprintf("Ensure you have write permissions for %s", binariusHome),
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	if err := migrateFiles(); err != nil {
+		_ = l.Release()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// migrateFiles writes back installation.json and the user's config.yaml if an
+// older Binarius wrote them. Loading migrates them in memory only; the files
+// are upgraded here, under the home lock, so readers never race with writers.
+// The system config.yaml belongs to the administrator and is never rewritten.
+func migrateFiles() error {
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range []struct {
+		path    string
+		migrate func(string) (bool, error)
+	}{
+		{registryPath, config.MigrateRegistryFile},
+		{configPath, config.MigrateConfigFile},
+	} {
+		migrated, err := file.migrate(file.path)
+		if config.IsNewerSchema(err) {
+			return newerSchemaError(filepath.Base(file.path), err)
+		}
+		if err != nil {
+			return utils.NewUserError(
+				fmt.Sprintf("Failed to upgrade %s to the current schema version", file.path),
+				err.Error(),
+				fmt.Sprintf("Ensure %s and its directory are writable", file.path),
+			).WithCode(utils.CodeFilesystem)
+		}
+		if migrated {
+			slog.Info("Upgraded file to the current schema version", "path", file.path)
+		}
+	}
+
+	return nil
+}
+
+// activeVersion returns the version the tool's symlink in binDir points to,
+// or "" if there is no symlink or its target isn't a Binarius installation.
+// Symlink targets have the form <tools-dir>/<tool>/<version>/<binary>.
+func activeVersion(binDir, toolName string) string {
+	target, err := os.Readlink(filepath.Join(binDir, toolName))
+	if err != nil {
+		return ""
+	}
+
+	parts := strings.Split(target, string(filepath.Separator))
+	for i, part := range parts {
+		if part == toolName && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// toolSpec is a parsed <tool>@<version> argument.
+type toolSpec struct {
+	Tool    string
+	Version string // Normalized, or "latest" if allowed
+}
+
+func (s toolSpec) String() string {
+	return s.Tool + "@" + s.Version
+}
+
+// parseSpec parses and validates a <tool>@<version> argument and normalizes
+// the version. If allowLatest is set, the version may also be "latest".
+func parseSpec(arg string, allowLatest bool) (toolSpec, error) {
+	example := "'terraform@v1.6.0'"
+	if allowLatest {
+		example = "'terraform@v1.6.0' or 'tofu@latest'"
+	}
+
+	parts := strings.Split(arg, "@")
+	if len(parts) != 2 {
+		return toolSpec{}, utils.NewUserError(
+			"Invalid argument format",
+			fmt.Sprintf("Expected format: <tool>@<version>, got: %s", arg),
+			fmt.Sprintf("Use format like %s", example),
+		).WithCode(utils.CodeUsage)
+	}
+	spec := toolSpec{Tool: parts[0], Version: parts[1]}
+
+	if err := utils.ValidateToolName(spec.Tool); err != nil {
+		return toolSpec{}, utils.NewUserError(
+			"Invalid tool name",
+			err.Error(),
+			"Tool name must be lowercase alphanumeric with hyphens only",
+		).WithCode(utils.CodeUsage)
+	}
+
+	if allowLatest && spec.Version == "latest" {
+		return spec, nil
+	}
+
+	version, err := utils.NormalizeVersion(spec.Version)
+	if err != nil {
+		return toolSpec{}, utils.NewUserError(
+			"Invalid version format",
+			err.Error(),
+			"Version must follow semantic versioning (e.g., v1.6.0, 1.6.0-beta1)",
+		).WithCode(utils.CodeUsage)
+	}
+	spec.Version = version
+
+	return spec, nil
+}
+
+// parseSpecs parses every argument with parseSpec, dropping duplicates.
+func parseSpecs(args []string, allowLatest bool) ([]toolSpec, error) {
+	var specs []toolSpec
+	for _, arg := range args {
+		spec, err := parseSpec(arg, allowLatest)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(specs, spec) {
+			specs = append(specs, spec)
+		}
+	}
+	return specs, nil
+}
+
+// batchError summarizes the failures of a command that carried on after some
+// of its items failed, e.g. batchError("use", "activate", "version", ...). Its
+// code is that of the first failure.
+func batchError(command, verb, noun string, failed []string, total int, firstErr error) error {
+	return utils.NewUserError(
+		fmt.Sprintf("Failed to %s %d of %d %s(s)", verb, len(failed), total, noun),
+		fmt.Sprintf("Failed: %s", strings.Join(failed, ", ")),
+		fmt.Sprintf("See the errors above, then run 'binarius %s' again for those %ss", command, noun),
+	).WithCode(utils.CodeOf(firstErr))
+}
+
+// printfFunc prints progress messages like messagef.
+type printfFunc func(format string, a ...interface{})
+
+// prefixedMessages returns a printfFunc that prints each non-empty line with
+// a "[prefix]" tag, so the progress of concurrent operations can be told apart.
+func prefixedMessages(prefix string) printfFunc {
+	return func(format string, a ...interface{}) {
+		for _, line := range strings.Split(fmt.Sprintf(format, a...), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				messagef("[%s] %s\n", prefix, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupTestHome points HOME, the XDG directories, and the Binarius
+// directories at a temporary directory, and returns it. The Binarius home is
+// <dir>/.binarius and the bin directory <dir>/bin.
+func setupTestHome(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tmpDir, "cache"))
+	t.Setenv("BINARIUS_LAYOUT", "")
+	t.Setenv("BINARIUS_HOME", filepath.Join(tmpDir, ".binarius"))
+	t.Setenv("BINARIUS_BIN_DIR", filepath.Join(tmpDir, "bin"))
+	t.Setenv("BINARIUS_CACHE_DIR", "")
+	return tmpDir
+}
+
+// runCommand runs binarius with args and returns what it wrote to stdout and
+// stderr.
+func runCommand(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	rootCmd.SetArgs(args)
+	var err error
+	stdout, stderr := captureOutput(t, func() {
+		err = Execute()
+	})
+	return stdout, stderr, err
+}
+
+// captureOutput runs fn and returns what it wrote to stdout and stderr.
+func captureOutput(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+
+	capture := func(f **os.File) (func() string, error) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, err
+		}
+		orig := *f
+		*f = w
+		done := make(chan string)
+		go func() {
+			data, _ := io.ReadAll(r)
+			done <- string(data)
+		}()
+		return func() string {
+			*f = orig
+			_ = w.Close()
+			return <-done
+		}, nil
+	}
+
+	stopStdout, err := capture(&os.Stdout)
+	if err != nil {
+		t.Fatalf("Failed to capture stdout: %v", err)
+	}
+	stopStderr, err := capture(&os.Stderr)
+	if err != nil {
+		stopStdout()
+		t.Fatalf("Failed to capture stderr: %v", err)
+	}
+
+	fn()
+	return stopStdout(), stopStderr()
+}
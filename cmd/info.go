@@ -7,8 +7,8 @@ import (
 	"strings"
 
 	"github.com/nixknight/binarius/internal/utils"
-	"github.com/nixknight/binarius/pkg/config"
 	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/nixknight/binarius/pkg/symlink"
 	"github.com/spf13/cobra"
 )
 
@@ -24,11 +24,13 @@ Shows:
   - Binary size
   - Source URL
   - Architecture
+  - Installation status
 
 Example:
   binarius info terraform`,
-	Args: cobra.ExactArgs(1),
-	RunE: runInfo,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeToolName,
+	RunE:              runInfo,
 }
 
 func init() {
@@ -44,29 +46,23 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			"Invalid tool name",
 			err.Error(),
 			"Tool name must be lowercase alphanumeric with hyphens only",
-		)
+		).WithCode(utils.CodeUsage)
 	}
 
 	// Get paths
-	binariusHome, err := paths.BinariusHome()
+	registryPath, err := paths.RegistryFile()
 	if err != nil {
 		return err
 	}
-
-	registryPath := filepath.Join(binariusHome, "installation.json")
 	binDir, err := paths.BinDir()
 	if err != nil {
 		return err
 	}
 
 	// Load registry
-	registry, err := config.LoadRegistry(registryPath)
+	registry, err := loadRegistry(registryPath)
 	if err != nil {
-		return utils.NewUserError(
-			"Failed to load installation registry",
-			err.Error(),
-			"Run 'binarius init' to initialize Binarius",
-		)
+		return err
 	}
 
 	// Check if tool has any installed versions
@@ -76,7 +72,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			fmt.Sprintf("No versions of %s are installed", toolName),
 			"Tool not found in registry",
 			fmt.Sprintf("Run 'binarius install %s@<version>' to install it", toolName),
-		)
+		).WithCode(utils.CodeNotInstalled)
 	}
 
 	// Resolve symlink to get active version
@@ -87,7 +83,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			fmt.Sprintf("No active version of %s", toolName),
 			"Symlink not found or broken",
 			fmt.Sprintf("Run 'binarius use %s@<version>' to activate a version", toolName),
-		)
+		).WithCode(utils.CodeNotInstalled)
 	}
 
 	// Extract version from symlink target path
@@ -116,7 +112,23 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			fmt.Sprintf("Version %s@%s not found in registry", toolName, activeVersion),
 			"Registry may be corrupted",
 			fmt.Sprintf("Try reinstalling: binarius install %s@%s", toolName, activeVersion),
-		)
+		).WithCode(utils.CodeNotInstalled)
+	}
+
+	if structuredOutput() {
+		_, statErr := os.Stat(toolVersion.BinaryPath)
+		manager := &symlink.Manager{}
+		return writeOutput(infoOutput{
+			Tool:          toolName,
+			ActiveVersion: activeVersion,
+			Symlink: symlinkState{
+				Path:   symlinkPath,
+				Target: target,
+				Valid:  manager.Verify(symlinkPath, toolVersion.BinaryPath) == nil,
+			},
+			BinaryExists: statErr == nil,
+			Installation: installationOutput(toolVersion),
+		})
 	}
 
 	// Display information
@@ -124,6 +136,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Active Version: %s\n", activeVersion)
 	fmt.Printf("Binary Path: %s\n", toolVersion.BinaryPath)
 	fmt.Printf("Symlink: %s -> %s\n", symlinkPath, target)
+	fmt.Printf("Status: %s\n", toolVersion.StatusOrDefault())
 
 	if !toolVersion.InstalledAt.IsZero() {
 		fmt.Printf("Installed: %s\n", toolVersion.InstalledAt.Format("2006-01-02 15:04:05"))
@@ -145,6 +158,10 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Checksum: %s\n", toolVersion.Checksum)
 	}
 
+	if !toolVersion.IsComplete() {
+		fmt.Printf("\n⚠️  WARNING: This installation is %s. Reinstall it with 'binarius install %s@%s'\n", toolVersion.Status, toolName, activeVersion)
+	}
+
 	// Verify binary still exists
 	if _, err := os.Stat(toolVersion.BinaryPath); err != nil {
 		fmt.Printf("\n⚠️  WARNING: Binary file not found at %s\n", toolVersion.BinaryPath)
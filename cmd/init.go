@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/nixknight/binarius/internal/utils"
 	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/doctor"
 	"github.com/nixknight/binarius/pkg/paths"
 	"github.com/spf13/cobra"
 )
@@ -18,7 +18,7 @@ var initCmd = &cobra.Command{
 	Long: `Initialize Binarius by creating the necessary directory structure and configuration files.
 
 This command:
-  - Creates ~/.binarius directory and subdirectories
+  - Creates the Binarius directories (~/.binarius, or the XDG directories)
   - Creates default config.yaml
   - Creates empty installation.json registry
   - Verifies that ~/.local/bin is in your PATH`,
@@ -37,7 +37,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 			"Failed to determine Binarius home directory",
 			err.Error(),
 			"Ensure your HOME environment variable is set correctly",
-		)
+		).WithCode(utils.CodeConfig)
 	}
 
 	toolsDir, err := paths.ToolsDir()
@@ -55,24 +55,45 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+
 	// Create directories
-	dirs := []string{binariusHome, toolsDir, cacheDir, binDir}
+	dirs := []string{filepath.Dir(configPath), binariusHome, toolsDir, cacheDir, binDir}
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return utils.NewUserError(
 				fmt.Sprintf("Failed to create directory: %s", dir),
 				err.Error(),
 				"Ensure you have write permissions for your home directory",
-			)
+			).WithCode(utils.CodeFilesystem)
 		}
 	}
 
-	// Create default config.yaml
-	configPath := filepath.Join(binariusHome, "config.yaml")
+	// Hold the home lock while creating config.yaml and installation.json
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	// Create default config.yaml. Directories given as flags are recorded so
+	// later commands use them too; everything else is left to the defaults of
+	// the current layout, so it can still be changed with migrate-layout.
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		defaultConfig, err := config.DefaultConfig()
-		if err != nil {
-			return err
+		defaultConfig := config.New()
+		if binDirFlag != "" {
+			defaultConfig.Paths.BinDir = binDir
+		}
+		if cacheDirFlag != "" {
+			defaultConfig.Paths.CacheDir = cacheDir
 		}
 
 		if err := config.Save(defaultConfig, configPath); err != nil {
@@ -80,7 +101,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 				"Failed to create config.yaml",
 				err.Error(),
 				"Ensure you have write permissions for ~/.binarius",
-			)
+			).WithCode(utils.CodeFilesystem)
 		}
 		fmt.Printf("Created config.yaml at %s\n", configPath)
 	} else {
@@ -88,7 +109,6 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create empty installation.json registry
-	registryPath := filepath.Join(binariusHome, "installation.json")
 	if _, err := os.Stat(registryPath); os.IsNotExist(err) {
 		registry := config.NewRegistry()
 		if err := config.SaveRegistry(registry, registryPath); err != nil {
@@ -96,7 +116,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 				"Failed to create installation.json",
 				err.Error(),
 				"Ensure you have write permissions for ~/.binarius",
-			)
+			).WithCode(utils.CodeFilesystem)
 		}
 		fmt.Printf("Created installation.json at %s\n", registryPath)
 	} else {
@@ -104,8 +124,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if binDir is in PATH
-	pathEnv := os.Getenv("PATH")
-	if !strings.Contains(pathEnv, binDir) {
+	if !doctor.InPath(binDir, os.Getenv("PATH")) {
 		fmt.Printf("\n⚠️  WARNING: %s is not in your PATH\n", binDir)
 		fmt.Println("\nAdd the following to your shell configuration (~/.bashrc or ~/.zshrc):")
 		fmt.Printf("    export PATH=\"%s:$PATH\"\n", binDir)
@@ -2,335 +2,866 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/nixknight/binarius/internal/utils"
 	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/httpclient"
 	"github.com/nixknight/binarius/pkg/installer"
 	"github.com/nixknight/binarius/pkg/paths"
 	"github.com/nixknight/binarius/pkg/tools"
 	"github.com/spf13/cobra"
 )
 
+var (
+	installFromFile  string
+	installChecksums string
+	installJobs      int
+)
+
 var installCmd = &cobra.Command{
-	Use:   "install <tool>@<version>",
-	Short: "Install a tool version",
-	Long: `Install a specific version of a tool.
+	Use:   "install <tool>@<version>...",
+	Short: "Install tool versions",
+	Long: `Install specific versions of tools.
+
+With several versions, downloads run concurrently (up to --jobs at a time),
+the result of each version is reported, and the versions are recorded as
+complete in the installation registry with a single save at the end. A version
+that fails doesn't stop the others.
 
 Examples:
   binarius install terraform@v1.6.0
   binarius install tofu@latest
-  binarius install terragrunt@v0.54.0
+  binarius install terraform@1.6.0 tofu@1.6.2 terragrunt@0.54.0
+
+Offline installation from a local archive or binary:
+  binarius install terraform@1.6.0 --from-file ./terraform_1.6.0_linux_amd64.zip
+  binarius install terraform@1.6.0 --from-file ./terraform_1.6.0_linux_amd64.zip --checksums ./terraform_1.6.0_SHA256SUMS
 
 The tool binary will be downloaded, verified, and installed to ~/.binarius/tools/<tool>/<version>/`,
-	Args: cobra.ExactArgs(1),
-	RunE: runInstall,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeToolVersions(availableVersions),
+	RunE:              runInstall,
 }
 
 func init() {
+	installCmd.Flags().StringVar(&installFromFile, "from-file", "", "Install from a local archive or binary instead of downloading")
+	installCmd.Flags().StringVar(&installChecksums, "checksums", "", "Verify against a local SHA256SUMS file instead of downloading one")
+	installCmd.Flags().IntVarP(&installJobs, "jobs", "j", 4, "Number of versions to download and verify at a time")
 	rootCmd.AddCommand(installCmd)
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
-	// Parse tool@version
-	parts := strings.Split(args[0], "@")
-	if len(parts) != 2 {
+	if len(args) > 1 && (installFromFile != "" || installChecksums != "") {
 		return utils.NewUserError(
-			"Invalid argument format",
-			fmt.Sprintf("Expected format: <tool>@<version>, got: %s", args[0]),
-			"Use format like 'terraform@v1.6.0' or 'tofu@latest'",
-		)
+			"Too many versions for an offline installation",
+			"--from-file and --checksums apply to a single version",
+			"Run 'binarius install' once for each local file",
+		).WithCode(utils.CodeUsage)
+	}
+	if installJobs < 1 {
+		return utils.NewUserError(
+			"Invalid --jobs value",
+			fmt.Sprintf("--jobs must be at least 1, got %d", installJobs),
+			"Use --jobs 1 to install one version at a time",
+		).WithCode(utils.CodeUsage)
 	}
 
-	toolName := parts[0]
-	version := parts[1]
+	specs, err := parseSpecs(args, true)
+	if err != nil {
+		return err
+	}
 
-	// Validate tool name
-	if err := utils.ValidateToolName(toolName); err != nil {
+	// Resolving "latest" needs the network, which offline installs don't have
+	if specs[0].Version == "latest" && installFromFile != "" {
 		return utils.NewUserError(
-			"Invalid tool name",
-			err.Error(),
-			"Tool name must be lowercase alphanumeric with hyphens only",
-		)
+			"Cannot resolve 'latest' for an offline installation",
+			"--from-file requires an explicit version",
+			fmt.Sprintf("Specify the version of the local file, e.g. '%s@v1.6.0'", specs[0].Tool),
+		).WithCode(utils.CodeUsage)
 	}
 
-	// Get tool from registry
-	tool, err := tools.Get(toolName)
+	// Load configuration for mirror settings
+	cfg, err := loadConfig()
 	if err != nil {
-		return utils.NewUserError(
-			fmt.Sprintf("Tool '%s' is not supported", toolName),
-			err.Error(),
-			fmt.Sprintf("Supported tools: %s", strings.Join(tools.List(), ", ")),
-		)
+		return err
 	}
 
-	// Resolve version if it's "latest"
-	if version == "latest" {
-		fmt.Printf("Resolving latest version for %s...\n", toolName)
-		versions, err := tool.ListVersions()
-		if err != nil {
-			return utils.NewUserError(
-				"Failed to fetch available versions",
-				err.Error(),
-				"Check your internet connection and try again",
-			)
-		}
+	if len(specs) > 1 {
+		return installBatch(cfg, specs)
+	}
 
-		if len(versions) == 0 {
-			return utils.NewUserError(
-				"No versions found",
-				fmt.Sprintf("No versions available for %s", toolName),
-				"Contact the tool maintainer or check the official website",
-			)
-		}
+	// Get tool from registry, routed through any configured mirror
+	tool, err := resolveTool(cfg, specs[0].Tool)
+	if err != nil {
+		return err
+	}
 
-		version = versions[0] // First version is the latest
-		fmt.Printf("Latest version: %s\n", version)
+	version, err := resolveVersion(tool, specs[0], messagef)
+	if err != nil {
+		return err
 	}
 
+	toolVersion, alreadyInstalled, err := installVersion(cfg, tool, specs[0].Tool, version)
+	if err != nil {
+		return err
+	}
+	if !alreadyInstalled {
+		messagef("\nTo use this version, run:\n    binarius use %s@%s\n", specs[0].Tool, version)
+	}
+	return writeInstallOutput(toolVersion, alreadyInstalled)
+}
+
+// resolveVersion returns the version a spec asks for, looking up the latest
+// release for "latest".
+func resolveVersion(tool tools.Tool, spec toolSpec, say printfFunc) (string, error) {
+	if spec.Version != "latest" {
+		return spec.Version, nil
+	}
+
+	say("Resolving latest version for %s...\n", spec.Tool)
+	versions, err := tool.ListVersions()
+	if err != nil {
+		return "", utils.NewUserError(
+			"Failed to fetch available versions",
+			err.Error(),
+			"Check your internet connection and try again",
+		).WithCode(httpclient.ErrorCode(err))
+	}
+
+	if len(versions) == 0 {
+		return "", utils.NewUserError(
+			"No versions found",
+			fmt.Sprintf("No versions available for %s", spec.Tool),
+			"Contact the tool maintainer or check the official website",
+		).WithCode(utils.CodeNotFound)
+	}
+
+	version := versions[0] // First version is the latest
+	say("Latest version: %s\n", version)
+
 	// Normalize version (ensure 'v' prefix)
 	version, err = utils.NormalizeVersion(version)
 	if err != nil {
-		return utils.NewUserError(
+		return "", utils.NewUserError(
 			"Invalid version format",
 			err.Error(),
 			"Version must follow semantic versioning (e.g., v1.6.0, 1.6.0-beta1)",
-		)
+		).WithCode(utils.CodeUsage)
 	}
+	return version, nil
+}
 
-	// Get paths
-	binariusHome, err := paths.BinariusHome()
+// installVersion downloads (or, with --from-file, copies), verifies, and
+// installs a version of a tool, and records it in the installation registry.
+// version must be normalized. Returns the registry entry and whether the
+// version was already installed.
+func installVersion(cfg *config.Config, tool tools.Tool, toolName, version string) (config.ToolVersion, bool, error) {
+	stagings := &stagingSet{}
+	stopInterruptHandler := cleanupOnInterrupt(stagings.cleanup)
+	defer stopInterruptHandler()
+	defer stagings.cleanup()
+
+	pending, err := stageInstall(cfg, tool, toolName, version, messagef, stagings)
 	if err != nil {
-		return err
+		return config.ToolVersion{}, false, err
+	}
+
+	if err := commitInstalls([]*pendingInstall{pending}); err != nil {
+		return config.ToolVersion{}, false, err
 	}
+	if pending.err != nil {
+		return config.ToolVersion{}, false, pending.err
+	}
+
+	return pending.toolVersion, pending.alreadyInstalled, nil
+}
+
+// installBatch installs several versions. Resolving "latest", downloads,
+// verification, and extraction run concurrently on up to --jobs workers; the
+// installations are then moved into place and recorded with a single registry
+// save. A failed version doesn't stop the others, and a version requested
+// twice (e.g. as "latest" and by number) is installed once.
+func installBatch(cfg *config.Config, specs []toolSpec) error {
+	stagings := &stagingSet{}
+	stopInterruptHandler := cleanupOnInterrupt(stagings.cleanup)
+	defer stopInterruptHandler()
+	defer stagings.cleanup()
+
+	// Resolve every spec to a tool and a concrete version
+	resolved := make([]toolSpec, len(specs))
+	resolvedTools := make([]tools.Tool, len(specs))
+	errs := make([]error, len(specs))
+	runJobs(len(specs), func(i int) {
+		resolvedTools[i], resolved[i], errs[i] = resolveSpec(cfg, specs[i])
+	})
+
+	// Stage each version once
+	var unique []int
+	seen := make(map[toolSpec]bool)
+	for i, spec := range resolved {
+		if errs[i] != nil {
+			unique = append(unique, i)
+			continue
+		}
+		if seen[spec] {
+			prefixedMessages(specs[i].String())("%s is already being installed\n", spec)
+			continue
+		}
+		seen[spec] = true
+		unique = append(unique, i)
+	}
+
+	pending := make([]*pendingInstall, len(specs))
+	runJobs(len(unique), func(j int) {
+		i := unique[j]
+		if errs[i] == nil {
+			spec := resolved[i]
+			pending[i], errs[i] = stageInstall(cfg, resolvedTools[i], spec.Tool, spec.Version, prefixedMessages(specs[i].String()), stagings)
+		}
+	})
 
-	registryPath := filepath.Join(binariusHome, "installation.json")
+	var staged []*pendingInstall
+	for _, p := range pending {
+		if p != nil {
+			staged = append(staged, p)
+		}
+	}
+	commitErr := commitInstalls(staged)
+
+	report := installBatchOutput{Installs: make([]installResult, 0, len(unique))}
+	var failed []string
+	var firstErr error
+	for _, i := range unique {
+		result := installResult{Spec: specs[i].String()}
+		if resolved[i].Version != "" {
+			result.Spec = resolved[i].String()
+		}
+		err := errs[i]
+		if p := pending[i]; p != nil {
+			switch {
+			case p.err != nil:
+				err = p.err
+			case commitErr != nil && !p.alreadyInstalled:
+				err = commitErr
+			default:
+				installation := installationOutput(p.toolVersion)
+				result.Installation = &installation
+				result.AlreadyInstalled = p.alreadyInstalled
+			}
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+			fmt.Fprintf(os.Stderr, "✗ Failed to install %s:\n%s\n\n", result.Spec, err)
+			failed = append(failed, result.Spec)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		report.Installs = append(report.Installs, result)
+	}
+
+	if structuredOutput() {
+		if err := writeOutput(report); err != nil {
+			return err
+		}
+	} else {
+		printInstallBatch(report)
+	}
+
+	if len(failed) > 0 {
+		return batchError("install", "install", "version", failed, len(report.Installs), firstErr)
+	}
+	return nil
+}
+
+// resolveSpec returns the tool of a spec and the spec with "latest" resolved,
+// prefixing progress messages with the spec.
+func resolveSpec(cfg *config.Config, spec toolSpec) (tools.Tool, toolSpec, error) {
+	tool, err := resolveTool(cfg, spec.Tool)
+	if err != nil {
+		return nil, toolSpec{}, err
+	}
+
+	version, err := resolveVersion(tool, spec, prefixedMessages(spec.String()))
+	if err != nil {
+		return nil, toolSpec{}, err
+	}
+
+	return tool, toolSpec{Tool: spec.Tool, Version: version}, nil
+}
+
+// runJobs calls fn for 0 through n-1 on up to --jobs workers and waits for
+// them to finish.
+func runJobs(n int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(installJobs, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// printInstallBatch prints one line per version of a batch install.
+func printInstallBatch(report installBatchOutput) {
+	messagef("\n")
+	var installed []string
+	for _, result := range report.Installs {
+		switch {
+		case result.Error != "":
+			messagef("✗ %s failed\n", result.Spec)
+		case result.AlreadyInstalled:
+			messagef("✓ %s was already installed\n", result.Spec)
+		default:
+			messagef("✓ %s installed\n", result.Spec)
+			installed = append(installed, result.Spec)
+		}
+	}
+
+	if len(installed) > 0 {
+		messagef("\nTo use these versions, run:\n    binarius use %s\n", strings.Join(installed, " "))
+	}
+}
+
+// pendingInstall is a version that has been downloaded, verified, and
+// extracted into a staging directory, ready to be moved into place.
+type pendingInstall struct {
+	toolName         string
+	version          string
+	versionDir       string
+	staging          *installer.Staging // nil if alreadyInstalled
+	toolVersion      config.ToolVersion
+	alreadyInstalled bool
+	say              printfFunc
+	err              error // Set by commitInstalls if the version couldn't be moved into place
+}
+
+// stagingSet tracks the staging directories of concurrent installs, so they
+// can all be removed on interrupt.
+type stagingSet struct {
+	mu       sync.Mutex
+	stagings []*installer.Staging
+}
+
+func (s *stagingSet) add(staging *installer.Staging) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stagings = append(s.stagings, staging)
+}
+
+// cleanup removes every staging directory that hasn't been committed.
+func (s *stagingSet) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, staging := range s.stagings {
+		staging.Cleanup()
+	}
+}
+
+// stageInstall downloads (or, with --from-file, copies), verifies, and
+// extracts a version of a tool into a staging directory, which is added to
+// stagings. version must be normalized. Nothing is staged if the version is
+// already installed.
+func stageInstall(cfg *config.Config, tool tools.Tool, toolName, version string, say printfFunc, stagings *stagingSet) (*pendingInstall, error) {
+	// Verification policy from config.yaml (strict, checksum, or none)
+	policy, err := verificationPolicy(cfg, toolName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get paths
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return nil, err
+	}
 	cacheDir, err := paths.CacheDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	toolsDir, err := paths.ToolsDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Load registry
-	registry, err := config.LoadRegistry(registryPath)
+	registry, err := loadRegistry(registryPath)
 	if err != nil {
-		return utils.NewUserError(
-			"Failed to load installation registry",
-			err.Error(),
-			"Run 'binarius init' to initialize Binarius",
-		)
+		return nil, err
+	}
+
+	pending := &pendingInstall{
+		toolName:   toolName,
+		version:    version,
+		versionDir: filepath.Join(toolsDir, toolName, version),
+		say:        say,
 	}
 
-	// Check if already installed
+	// Check if already installed; partial or broken installations are reinstalled
+	if registry.IsUsable(toolName, version) {
+		say("✓ %s@%s is already installed\n", toolName, version)
+		pending.toolVersion = registry.GetVersion(toolName, version)
+		pending.alreadyInstalled = true
+		return pending, nil
+	}
 	if registry.IsInstalled(toolName, version) {
-		fmt.Printf("✓ %s@%s is already installed\n", toolName, version)
-		return nil
+		say("Reinstalling %s@%s (status: %s)\n", toolName, version, registry.GetVersion(toolName, version).Status)
 	}
 
-	// Get download URL
 	osName := runtime.GOOS
 	arch := runtime.GOARCH
-	downloadURL := tool.GetDownloadURL(version, osName, arch)
 
-	fmt.Printf("Installing %s@%s for %s/%s...\n", toolName, version, osName, arch)
-	fmt.Printf("Download URL: %s\n", downloadURL)
+	var archivePath, sourceURL string
+	if installFromFile != "" {
+		// Use the local file as the archive
+		archivePath, err = filepath.Abs(installFromFile)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(archivePath); err != nil {
+			return nil, utils.NewUserError(
+				fmt.Sprintf("Local file not found: %s", installFromFile),
+				err.Error(),
+				"Check the path passed to --from-file",
+			).WithCode(utils.CodeUsage)
+		}
+		sourceURL = (&url.URL{Scheme: "file", Path: archivePath}).String()
+
+		say("Installing %s@%s for %s/%s...\n", toolName, version, osName, arch)
+		say("Source file: %s\n", archivePath)
+	} else {
+		// Get download URL
+		sourceURL = tool.GetDownloadURL(version, osName, arch)
+
+		say("Installing %s@%s for %s/%s...\n", toolName, version, osName, arch)
+		say("Download URL: %s\n", sourceURL)
+
+		// Determine archive filename from URL
+		urlParts := strings.Split(sourceURL, "/")
+		archiveName := urlParts[len(urlParts)-1]
+		archivePath = filepath.Join(cacheDir, archiveName)
+
+		// Download archive
+		say("Downloading...\n")
+		if err := installer.Download(sourceURL, archivePath); err != nil {
+			return nil, err
+		}
+		say("✓ Download complete\n")
+	}
 
-	// Determine archive filename from URL
-	urlParts := strings.Split(downloadURL, "/")
-	archiveName := urlParts[len(urlParts)-1]
-	archivePath := filepath.Join(cacheDir, archiveName)
+	// Obtain the checksum file, either locally or from upstream
+	checksumPath := installChecksums
+	switch {
+	case checksumPath != "":
+		if _, err := os.Stat(checksumPath); err != nil {
+			return nil, utils.NewUserError(
+				fmt.Sprintf("Checksum file not found: %s", checksumPath),
+				err.Error(),
+				"Check the path passed to --checksums",
+			).WithCode(utils.CodeUsage)
+		}
+	case installFromFile == "" && policy != config.PolicyNone:
+		checksumURL := tool.GetChecksumURL(version, osName, arch)
+		checksumPath = filepath.Join(cacheDir, fmt.Sprintf("%s-%s.sha256sums", toolName, version))
+
+		say("Downloading checksums...\n")
+		if err := installer.Download(checksumURL, checksumPath); err != nil {
+			return nil, utils.NewUserError(
+				"Failed to download checksum file",
+				err.Error(),
+				fmt.Sprintf("Could not download checksums from %s. Check your internet connection.", checksumURL),
+			).WithCode(httpclient.ErrorCode(err))
+		}
+	}
 
-	// Download archive
-	fmt.Println("Downloading...")
-	if err := installer.Download(downloadURL, archivePath); err != nil {
-		return err
+	verifiers, err := buildVerifiers(cfg, tool, version, osName, arch)
+	if err != nil {
+		return nil, err
 	}
-	fmt.Println("✓ Download complete")
 
-	// Download checksum file and verify
-	checksumURL := tool.GetChecksumURL(version, osName, arch)
-	checksumPath := filepath.Join(cacheDir, fmt.Sprintf("%s-%s.sha256sums", toolName, version))
+	artifact := &installer.Artifact{
+		Path:         archivePath,
+		ChecksumFile: checksumPath,
+		Names:        []string{filepath.Base(archivePath)},
+	}
+	if installFromFile != "" {
+		// The local file may have been renamed; fall back to the upstream file name
+		artifact.Names = append(artifact.Names, path.Base(tool.GetDownloadURL(version, osName, arch)))
+	}
 
-	fmt.Println("Downloading checksums...")
-	if err := installer.Download(checksumURL, checksumPath); err != nil {
-		return utils.NewUserError(
-			"Failed to download checksum file",
-			err.Error(),
-			fmt.Sprintf("Could not download checksums from %s. Check your internet connection.", checksumURL),
-		)
+	pipeline := installer.Pipeline{
+		Verifiers: verifiers,
+		Policy:    policy,
+		OnResult:  verificationPrinter(say),
+	}
+
+	if policy == config.PolicyNone {
+		say("⚠️  Verification is disabled for %s in config.yaml\n", toolName)
+	} else {
+		say("Verifying download integrity...\n")
 	}
 
-	// Parse checksum file to find the expected checksum
-	expectedChecksum, err := parseChecksumFile(checksumPath, filepath.Base(archivePath))
+	verification, err := pipeline.Run(artifact)
 	if err != nil {
-		return utils.NewUserError(
-			"Failed to parse checksum file",
-			err.Error(),
-			"The checksum file format may be invalid. Please report this issue.",
-		)
+		// Delete unverified downloads, but never the user's own files
+		if installFromFile == "" {
+			_ = os.Remove(archivePath)
+		}
+		if installChecksums == "" && checksumPath != "" {
+			for _, p := range []string{checksumPath, checksumPath + ".sig", checksumPath + ".pem"} {
+				_ = os.Remove(p)
+			}
+		}
+		return nil, err
 	}
 
-	// Verify checksum
-	fmt.Println("Verifying download integrity...")
-	if err := installer.VerifyChecksum(archivePath, expectedChecksum); err != nil {
-		// Delete corrupted file
-		_ = os.Remove(archivePath)
-		return utils.NewUserError(
-			"Checksum verification failed",
-			err.Error(),
-			"The downloaded file may be corrupted or tampered with. Please try downloading again.",
-		)
+	// Record the archive checksum; it matches the verified one unless verification was skipped
+	archiveChecksum, err := installer.ComputeSHA256(archivePath)
+	if err != nil {
+		return nil, err
 	}
-	fmt.Println("✓ Checksum verified")
 
-	// Create version directory
-	versionDir := filepath.Join(toolsDir, toolName, version)
-	if err := os.MkdirAll(versionDir, 0755); err != nil {
-		return utils.NewUserError(
-			fmt.Sprintf("Failed to create version directory: %s", versionDir),
+	// Extract into a staging directory next to the final version directory,
+	// so an interrupted install never leaves a half-populated version directory
+	staging, err := installer.NewStaging(pending.versionDir)
+	if err != nil {
+		return nil, utils.NewUserError(
+			fmt.Sprintf("Failed to create staging directory for %s", pending.versionDir),
 			err.Error(),
 			"Ensure you have write permissions for ~/.binarius",
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
+	stagings.add(staging)
 
-	// Extract archive based on format
+	// Extract archive based on format. A local file may be an archive or the
+	// binary itself, whatever the tool publishes, so its format is detected
 	archiveFormat := tool.GetArchiveFormat()
-	fmt.Printf("Extracting %s archive...\n", archiveFormat)
-
-	switch archiveFormat {
-	case "zip":
-		if err := installer.ExtractZip(archivePath, versionDir); err != nil {
-			return err
-		}
-	case "tar.gz":
-		if err := installer.ExtractTarGz(archivePath, versionDir); err != nil {
-			return err
-		}
-	case "binary":
-		// Direct binary, just copy it
-		binaryPath := filepath.Join(versionDir, tool.GetBinaryName())
-		if err := copyFile(archivePath, binaryPath); err != nil {
-			return utils.NewUserError(
-				"Failed to copy binary",
+	if installFromFile != "" {
+		if archiveFormat, err = installer.DetectArchiveFormat(archivePath); err != nil {
+			return nil, utils.NewUserError(
+				fmt.Sprintf("Failed to read local file: %s", installFromFile),
 				err.Error(),
-				"Ensure you have write permissions for ~/.binarius",
-			)
+				"Check the path passed to --from-file",
+			).WithCode(utils.CodeUsage)
 		}
-		if err := os.Chmod(binaryPath, 0755); err != nil {
-			return err
-		}
-	default:
-		return utils.NewUserError(
-			"Unsupported archive format",
-			fmt.Sprintf("Archive format '%s' is not supported", archiveFormat),
-			"This is a bug. Please report it to the maintainer.",
-		)
+	}
+	if archiveFormat == "binary" {
+		say("Copying binary...\n")
+	} else {
+		say("Extracting %s archive...\n", archiveFormat)
 	}
 
-	fmt.Println("✓ Extraction complete")
+	if err := installer.Unpack(archivePath, archiveFormat, staging.Dir, tool.GetBinaryName()); err != nil {
+		return nil, err
+	}
 
-	// Verify binary exists
-	binaryPath := filepath.Join(versionDir, tool.GetBinaryName())
-	binaryInfo, err := os.Stat(binaryPath)
-	if err != nil {
-		return utils.NewUserError(
+	say("✓ Extraction complete\n")
+
+	// Verify binary exists in the staging directory before moving it into place
+	stagedBinary := filepath.Join(staging.Dir, tool.GetBinaryName())
+	binaryInfo, err := os.Stat(stagedBinary)
+	if err != nil || !binaryInfo.Mode().IsRegular() {
+		return nil, utils.NewUserError(
 			"Binary not found after extraction",
-			fmt.Sprintf("Expected binary at %s, but it doesn't exist", binaryPath),
+			fmt.Sprintf("Expected binary %s in the archive, but it doesn't exist", tool.GetBinaryName()),
 			"The downloaded archive may not contain the expected binary",
 		)
 	}
 
-	// Update registry
-	toolVersion := config.ToolVersion{
-		ToolName:     toolName,
-		Version:      version,
-		BinaryPath:   binaryPath,
-		InstalledAt:  time.Now(),
-		SizeBytes:    binaryInfo.Size(),
-		SourceURL:    downloadURL,
-		Checksum:     expectedChecksum,
-		Architecture: fmt.Sprintf("%s/%s", osName, arch),
-		Status:       "complete",
+	// Record the binary's own checksum so 'binarius verify' can detect later tampering
+	binaryChecksum, err := installer.ComputeSHA256(stagedBinary)
+	if err != nil {
+		return nil, err
 	}
 
-	registry.AddVersion(toolName, version, toolVersion)
-	if err := config.SaveRegistry(registry, registryPath); err != nil {
-		return utils.NewUserError(
-			"Failed to update installation registry",
-			err.Error(),
-			"The installation completed but was not recorded. Run 'binarius init' and try again.",
-		)
+	pending.staging = staging
+	pending.toolVersion = config.ToolVersion{
+		ToolName:       toolName,
+		Version:        version,
+		BinaryPath:     filepath.Join(pending.versionDir, tool.GetBinaryName()),
+		InstalledAt:    time.Now(),
+		SizeBytes:      binaryInfo.Size(),
+		SourceURL:      sourceURL,
+		Checksum:       archiveChecksum,
+		BinaryChecksum: binaryChecksum,
+		Architecture:   fmt.Sprintf("%s/%s", osName, arch),
+		Status:         config.StatusComplete,
+		Verification:   verification,
 	}
 
-	fmt.Printf("\n✓ Successfully installed %s@%s\n", toolName, version)
-	fmt.Printf("Binary: %s\n", binaryPath)
-	fmt.Printf("\nTo use this version, run:\n    binarius use %s@%s\n", toolName, version)
-
-	return nil
+	return pending, nil
 }
 
-// parseChecksumFile reads a SHA256SUMS file and extracts the checksum for the given filename.
-// SHA256SUMS files follow the format: "checksum  filename" (two spaces between).
-func parseChecksumFile(checksumPath, targetFilename string) (string, error) {
-	data, err := os.ReadFile(checksumPath)
+// commitInstalls moves staged installations into place and records them in
+// the installation registry. Each version is first recorded as partial, so an
+// install killed mid-way is visible to 'binarius doctor' and 'binarius repair';
+// once the files are in place, all versions are marked complete with a single
+// registry save. A version that can't be moved into place gets its err set and
+// the others carry on; the returned error means the installations were rolled
+// back.
+func commitInstalls(pending []*pendingInstall) error {
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+
+	// Hold the home lock while moving files into place and updating the registry,
+	// so parallel installs can't lose each other's registry entries
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	// Reload the registry under the lock; another process may have changed it
+	registry, err := loadRegistry(registryPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read checksum file: %w", err)
+		return err
 	}
 
-	// Parse each line: "checksum  filename"
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	var toCommit []*pendingInstall
+	previous := make(map[*pendingInstall]config.ToolVersion)
+	for _, p := range pending {
+		if p.alreadyInstalled {
+			continue
+		}
+		if registry.IsUsable(p.toolName, p.version) {
+			p.say("✓ %s@%s was installed by another process\n", p.toolName, p.version)
+			p.toolVersion = registry.GetVersion(p.toolName, p.version)
+			p.alreadyInstalled = true
 			continue
 		}
 
-		// Split on whitespace
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
+		if tv, ok := registry.Tools[p.toolName][p.version]; ok {
+			previous[p] = tv
+		}
+		partial := p.toolVersion
+		partial.Status = config.StatusPartial
+		registry.AddVersion(p.toolName, p.version, partial)
+		toCommit = append(toCommit, p)
+	}
+
+	if len(toCommit) == 0 {
+		return nil
+	}
+
+	// Record the installations as partial until the files are in place
+	if err := config.SaveRegistry(registry, registryPath); err != nil {
+		return utils.NewUserError(
+			"Failed to update installation registry",
+			err.Error(),
+			"Ensure ~/.binarius is writable and try again",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	var committed []*pendingInstall
+	for _, p := range toCommit {
+		if err := commitStaged(p); err != nil {
+			p.err = err
+			// Restore the previous registry entry, if any
+			if tv, ok := previous[p]; ok {
+				registry.AddVersion(p.toolName, p.version, tv)
+			} else {
+				registry.RemoveVersion(p.toolName, p.version)
+			}
 			continue
 		}
 
-		checksum := parts[0]
-		filename := parts[1]
+		// Mark the installation complete
+		registry.AddVersion(p.toolName, p.version, p.toolVersion)
+		committed = append(committed, p)
+	}
 
-		if filename == targetFilename {
-			return checksum, nil
+	if err := config.SaveRegistry(registry, registryPath); err != nil {
+		// Roll back so complete files never sit behind a partial registry record
+		for _, p := range committed {
+			if rbErr := p.staging.Rollback(); rbErr != nil {
+				p.say("⚠️  Warning: %v\n", rbErr)
+			}
 		}
+		return utils.NewUserError(
+			"Failed to update installation registry",
+			err.Error(),
+			"The installation was rolled back. Ensure ~/.binarius is writable and try again.",
+		).WithCode(utils.CodeFilesystem)
 	}
 
-	return "", fmt.Errorf("checksum not found for %s in checksums file", targetFilename)
+	for _, p := range committed {
+		p.say("\n✓ Successfully installed %s@%s\n", p.toolName, p.version)
+		p.say("Binary: %s\n", p.toolVersion.BinaryPath)
+	}
+
+	return nil
 }
 
-// copyFile copies a file from src to dst
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
+// commitStaged atomically moves a staged installation into place, replacing
+// any leftover version directory.
+func commitStaged(p *pendingInstall) error {
+	// A leftover version directory belongs to an interrupted, partial or broken
+	// installation; it is safe to replace
+	if _, err := os.Lstat(p.versionDir); err == nil {
+		p.say("Removing incomplete installation at %s\n", p.versionDir)
+		if err := os.RemoveAll(p.versionDir); err != nil {
+			return utils.NewUserError(
+				fmt.Sprintf("Failed to remove incomplete installation: %s", p.versionDir),
+				err.Error(),
+				"Remove the directory manually and try again",
+			).WithCode(utils.CodeFilesystem)
+		}
 	}
-	defer func() { _ = srcFile.Close() }()
 
-	dstFile, err := os.Create(dst)
-	if err != nil {
-		return err
+	if err := p.staging.Commit(); err != nil {
+		return utils.NewUserError(
+			fmt.Sprintf("Failed to install %s@%s", p.toolName, p.version),
+			err.Error(),
+			"Ensure you have write permissions for ~/.binarius",
+		).WithCode(utils.CodeFilesystem)
 	}
+	return nil
+}
 
-	if _, err := dstFile.ReadFrom(srcFile); err != nil {
-		_ = dstFile.Close()
-		return err
+// writeInstallOutput writes the registry entry of an installation for --output json|yaml.
+func writeInstallOutput(toolVersion config.ToolVersion, alreadyInstalled bool) error {
+	if !structuredOutput() {
+		return nil
 	}
+	return writeOutput(installOutput{
+		AlreadyInstalled: alreadyInstalled,
+		Installation:     installationOutput(toolVersion),
+	})
+}
 
-	if err := dstFile.Close(); err != nil {
-		return err
+// buildVerifiers creates the verifiers for the verification methods a tool declares.
+// Signatures of downloaded checksum files are mandatory for tools that publish them;
+// signatures of local checksum files (--checksums) are checked if they sit next to the file.
+func buildVerifiers(cfg *config.Config, tool tools.Tool, version, osName, arch string) ([]installer.Verifier, error) {
+	downloaded := installFromFile == "" && installChecksums == ""
+
+	var verifiers []installer.Verifier
+	for _, method := range tools.VerificationMethods(tool) {
+		switch method {
+		case tools.VerifySHA256, tools.VerifySHA512:
+			v, err := installer.NewChecksumVerifier(method, installFromFile == "")
+			if err != nil {
+				return nil, err
+			}
+			verifiers = append(verifiers, v)
+
+		case tools.VerifyGPG:
+			signed, ok := tool.(tools.GPGSigned)
+			if !ok {
+				return nil, verifierBug(tool, method)
+			}
+			keys, err := trustedKeys(cfg, signed)
+			if err != nil {
+				return nil, err
+			}
+			v := &installer.GPGVerifier{Keys: keys, Mandatory: downloaded}
+			if downloaded {
+				v.SignatureURL = signed.GetChecksumSignatureURL(version, osName, arch)
+			}
+			verifiers = append(verifiers, v)
+
+		case tools.VerifyCosign:
+			signed, ok := tool.(tools.CosignSigned)
+			if !ok {
+				return nil, verifierBug(tool, method)
+			}
+			subjectPrefix, issuer := signed.CosignIdentity()
+			rekorURL, rekorKeys := signed.CosignTransparencyLog()
+			v := &installer.CosignVerifier{
+				Trust:    installer.CosignTrust{Roots: signed.CosignTrustRoots(), RekorKeys: rekorKeys, RekorURL: rekorURL},
+				Identity: installer.CertificateIdentity{SubjectPrefix: subjectPrefix, Issuer: issuer},
+			}
+			if downloaded {
+				v.BundleURL = signed.GetChecksumCosignBundleURL(version, osName, arch)
+				v.SignatureURL, v.CertificateURL = signed.GetChecksumCosignURLs(version, osName, arch)
+			}
+			verifiers = append(verifiers, v)
+
+		default:
+			return nil, verifierBug(tool, method)
+		}
 	}
 
-	return nil
+	return verifiers, nil
+}
+
+// verifierBug reports a tool declaring a verification method it can't support.
+func verifierBug(tool tools.Tool, method string) error {
+	return utils.NewUserError(
+		fmt.Sprintf("Unsupported verification method '%s' for %s", method, tool.GetName()),
+		"The tool declares a verification method without providing its material",
+		"This is a bug. Please report it to the maintainer.",
+	)
+}
+
+// verificationLabels are the human-readable names of verification methods.
+var verificationLabels = map[string]string{
+	tools.VerifySHA256: "SHA256 checksum",
+	tools.VerifySHA512: "SHA512 checksum",
+	tools.VerifyGPG:    "GPG signature",
+	tools.VerifyCosign: "Cosign signature",
+}
+
+// verificationPrinter returns a callback that reports the outcome of a single
+// verification step.
+func verificationPrinter(say printfFunc) func(name, detail string, err error) {
+	return func(name, detail string, err error) {
+		label := verificationLabels[name]
+		if label == "" {
+			label = name
+		}
+
+		if err != nil {
+			say("⚠️  Skipping %s verification: %v\n", label, err)
+			return
+		}
+
+		say("✓ %s verified (%s)\n", label, detail)
+	}
+}
+
+// cleanupOnInterrupt runs cleanup and exits if the process receives SIGINT or SIGTERM.
+// The returned function stops watching for signals and must be called once the
+// protected section is over.
+func cleanupOnInterrupt(cleanup func()) func() {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			fmt.Fprintf(os.Stderr, "\nReceived %s, cleaning up...\n", sig)
+			cleanup()
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
 }
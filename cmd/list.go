@@ -2,10 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
-	"strings"
 
 	"github.com/nixknight/binarius/internal/utils"
 	"github.com/nixknight/binarius/pkg/config"
@@ -24,8 +21,9 @@ With a tool name, lists only versions of that specific tool.
 Examples:
   binarius list              # List all tools and versions
   binarius list terraform    # List only terraform versions`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runList,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeToolName,
+	RunE:              runList,
 }
 
 func init() {
@@ -34,46 +32,27 @@ func init() {
 
 func runList(cmd *cobra.Command, args []string) error {
 	// Get paths
-	binariusHome, err := paths.BinariusHome()
+	registryPath, err := paths.RegistryFile()
 	if err != nil {
 		return err
 	}
 
-	registryPath := filepath.Join(binariusHome, "installation.json")
-
 	binDir, err := paths.BinDir()
 	if err != nil {
 		return err
 	}
 
 	// Load registry
-	registry, err := config.LoadRegistry(registryPath)
+	registry, err := loadRegistry(registryPath)
 	if err != nil {
-		return utils.NewUserError(
-			"Failed to load installation registry",
-			err.Error(),
-			"Run 'binarius init' to initialize Binarius",
-		)
+		return err
 	}
 
 	// Get active versions by reading symlinks
 	activeVersions := make(map[string]string)
-	allTools := registry.ListTools()
-	for _, tool := range allTools {
-		symlinkPath := filepath.Join(binDir, tool)
-		if target, err := os.Readlink(symlinkPath); err == nil {
-			// Extract version from symlink target path
-			// Format: ~/.binarius/tools/<tool>/<version>/<binary>
-			parts := strings.Split(target, string(filepath.Separator))
-			if len(parts) >= 2 {
-				// Find the version part (between tool name and binary name)
-				for i, part := range parts {
-					if part == tool && i+1 < len(parts) {
-						activeVersions[tool] = parts[i+1]
-						break
-					}
-				}
-			}
+	for _, tool := range registry.ListTools() {
+		if version := activeVersion(binDir, tool); version != "" {
+			activeVersions[tool] = version
 		}
 	}
 
@@ -87,18 +66,25 @@ func runList(cmd *cobra.Command, args []string) error {
 				"Invalid tool name",
 				err.Error(),
 				"Tool name must be lowercase alphanumeric with hyphens only",
-			)
+			).WithCode(utils.CodeUsage)
 		}
 
 		versions := registry.ListVersions(toolName)
+		if structuredOutput() {
+			output := listOutput{Tools: []listTool{}}
+			if len(versions) > 0 {
+				output.Tools = append(output.Tools, newListTool(registry, toolName, activeVersions[toolName]))
+			}
+			return writeOutput(output)
+		}
+
 		if len(versions) == 0 {
 			fmt.Printf("No versions of %s are installed\n", toolName)
 			fmt.Printf("\nTo install %s, run:\n    binarius install %s@<version>\n", toolName, toolName)
 			return nil
 		}
 
-		// Sort versions
-		sort.Strings(versions)
+		sortVersions(versions)
 
 		fmt.Printf("Installed versions of %s:\n", toolName)
 		for _, version := range versions {
@@ -106,7 +92,7 @@ func runList(cmd *cobra.Command, args []string) error {
 			if activeVersion, ok := activeVersions[toolName]; ok && activeVersion == version {
 				marker = "* " // Active version
 			}
-			fmt.Printf("%s %s\n", marker, version)
+			fmt.Printf("%s %s%s\n", marker, version, statusSuffix(registry.GetVersion(toolName, version)))
 		}
 
 		if activeVersion, ok := activeVersions[toolName]; ok {
@@ -121,6 +107,18 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	// List all tools
 	tools := registry.ListTools()
+
+	// Sort tools alphabetically
+	sort.Strings(tools)
+
+	if structuredOutput() {
+		output := listOutput{Tools: []listTool{}}
+		for _, tool := range tools {
+			output.Tools = append(output.Tools, newListTool(registry, tool, activeVersions[tool]))
+		}
+		return writeOutput(output)
+	}
+
 	if len(tools) == 0 {
 		fmt.Println("No tools installed")
 		fmt.Println("\nTo install a tool, run:")
@@ -130,15 +128,12 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Sort tools alphabetically
-	sort.Strings(tools)
-
 	fmt.Println("Installed tools and versions:")
 	fmt.Println()
 
 	for _, tool := range tools {
 		versions := registry.ListVersions(tool)
-		sort.Strings(versions)
+		sortVersions(versions)
 
 		activeVersion := ""
 		if av, ok := activeVersions[tool]; ok {
@@ -151,7 +146,7 @@ func runList(cmd *cobra.Command, args []string) error {
 			if version == activeVersion {
 				marker = "* " // Active version
 			}
-			fmt.Printf("%s %s\n", marker, version)
+			fmt.Printf("%s %s%s\n", marker, version, statusSuffix(registry.GetVersion(tool, version)))
 		}
 		fmt.Println()
 	}
@@ -160,3 +155,33 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// newListTool describes the installed versions of a tool for --output.
+func newListTool(registry *config.Registry, tool, activeVersion string) listTool {
+	versions := registry.ListVersions(tool)
+	sortVersions(versions)
+
+	entry := listTool{Name: tool, Active: activeVersion, Versions: []listVersion{}}
+	for _, version := range versions {
+		entry.Versions = append(entry.Versions, listVersion{
+			Version: version,
+			Status:  registry.GetVersion(tool, version).StatusOrDefault(),
+			Active:  version == activeVersion,
+		})
+	}
+	return entry
+}
+
+// sortVersions sorts versions from oldest to newest by semantic version, so
+// v1.10.0 comes after v1.9.0.
+func sortVersions(versions []string) {
+	sort.Slice(versions, func(i, j int) bool { return utils.CompareVersions(versions[i], versions[j]) < 0 })
+}
+
+// statusSuffix returns a marker for installations that aren't complete, e.g. " (broken)".
+func statusSuffix(tv config.ToolVersion) string {
+	if tv.IsComplete() {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", tv.Status)
+}
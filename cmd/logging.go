@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// logFile is the file opened for --log-file, closed by Execute
+var logFile *os.File
+
+// configureLogging installs the default slog logger used by pkg/installer,
+// pkg/tools, pkg/symlink, and pkg/httpclient, according to --verbose,
+// --quiet, and --log-file.
+func configureLogging() error {
+	if quietFlag && verboseFlag > 0 {
+		return utils.NewUserError(
+			"Conflicting flags",
+			"--quiet and --verbose can't be used together",
+			"Pass either --quiet or --verbose",
+		).WithCode(utils.CodeUsage)
+	}
+
+	// Commands that share a PersistentPreRunE may configure logging twice
+	if logFileFlag != "" && logFile == nil {
+		f, err := os.OpenFile(logFileFlag, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return utils.NewUserError(
+				fmt.Sprintf("Failed to open log file %s", logFileFlag),
+				err.Error(),
+				"Check the path passed to --log-file and that its directory is writable",
+			).WithCode(utils.CodeFilesystem)
+		}
+		logFile = f
+	}
+
+	// A nil *os.File must not become a non-nil io.Writer
+	var fileWriter io.Writer
+	if logFile != nil {
+		fileWriter = logFile
+	}
+	slog.SetDefault(utils.NewLogger(os.Stderr, logLevel(), fileWriter))
+
+	slog.Debug("Starting Binarius", "version", Version, "commit", GitCommit, "args", os.Args[1:])
+	return nil
+}
+
+// logLevel returns the console log level for --verbose and --quiet.
+// Warnings are shown by default.
+func logLevel() slog.Level {
+	switch {
+	case quietFlag:
+		return slog.LevelError
+	case verboseFlag >= 2:
+		return slog.LevelDebug
+	case verboseFlag == 1:
+		return slog.LevelInfo
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// closeLogFile closes the --log-file file, if one was opened.
+func closeLogFile() {
+	if logFile != nil {
+		_ = logFile.Close()
+		logFile = nil
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/layout"
+	"github.com/nixknight/binarius/pkg/lock"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateLayoutTo     string
+	migrateLayoutDryRun bool
+)
+
+var migrateLayoutCmd = &cobra.Command{
+	Use:   "migrate-layout",
+	Short: "Move Binarius files to the XDG or classic directory layout",
+	Long: `Move config.yaml, installation.json, installed tools and the download cache
+between directory layouts:
+
+  classic  Everything in ~/.binarius
+  xdg      config.yaml in $XDG_CONFIG_HOME/binarius (~/.config/binarius)
+           installation.json and tools in $XDG_DATA_HOME/binarius (~/.local/share/binarius)
+           Downloads in $XDG_CACHE_HOME/binarius (~/.cache/binarius)
+
+Registry entries and symlinks are updated to point at the moved binaries.
+A cache directory set with --cache-dir, BINARIUS_CACHE_DIR or config.yaml
+is left where it is.
+
+Examples:
+  binarius migrate-layout                 # Move ~/.binarius to the XDG layout
+  binarius migrate-layout --dry-run
+  binarius migrate-layout --to classic    # Move back to ~/.binarius`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateLayout,
+}
+
+func init() {
+	migrateLayoutCmd.Flags().StringVar(&migrateLayoutTo, "to", string(paths.LayoutXDG), "Layout to move to (xdg or classic)")
+	migrateLayoutCmd.Flags().BoolVar(&migrateLayoutDryRun, "dry-run", false, "Show what would be moved without changing anything")
+	rootCmd.AddCommand(migrateLayoutCmd)
+}
+
+func runMigrateLayout(cmd *cobra.Command, args []string) error {
+	target, err := paths.ParseLayout(migrateLayoutTo)
+	if err != nil {
+		return utils.NewUserError(
+			"Invalid layout",
+			err.Error(),
+			"Use --to xdg or --to classic",
+		).WithCode(utils.CodeUsage)
+	}
+
+	current, err := paths.CurrentLayout()
+	if err != nil {
+		return utils.NewUserError(
+			"Failed to determine the current layout",
+			err.Error(),
+			"Set BINARIUS_LAYOUT to 'classic' or 'xdg', or unset it",
+		).WithCode(utils.CodeConfig)
+	}
+
+	if current == target {
+		messagef("✓ Already using the %s layout\n", target)
+		return nil
+	}
+
+	from, err := paths.DefaultDirs(current)
+	if err != nil {
+		return err
+	}
+
+	to, err := paths.DefaultDirs(target)
+	if err != nil {
+		return err
+	}
+
+	// Only the default directories of a layout can be migrated; an explicit home stays put
+	binariusHome, err := paths.BinariusHome()
+	if err != nil {
+		return err
+	}
+
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if binariusHome != from.Data || filepath.Dir(configPath) != from.Config {
+		return utils.NewUserError(
+			"Can't migrate a custom Binarius home",
+			fmt.Sprintf("Binarius is using %s instead of the %s layout's default directories", binariusHome, current),
+			"Unset --home, BINARIUS_HOME and 'paths.binarius_home' in config.yaml, or move the directory yourself",
+		).WithCode(utils.CodeUsage)
+	}
+
+	cacheDir, err := paths.CacheDir()
+	if err != nil {
+		return err
+	}
+	if cacheDir != from.Cache {
+		messagef("Keeping the cache at %s (set explicitly)\n", cacheDir)
+		from.Cache = ""
+	}
+
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return err
+	}
+
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	moves, err := layout.Plan(from, to)
+	if err != nil {
+		return utils.NewUserError(
+			fmt.Sprintf("Can't move Binarius files to the %s layout", target),
+			err.Error(),
+			"Remove or move the existing files out of the way, then run 'binarius migrate-layout' again",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	if len(moves) == 0 {
+		messagef("No Binarius files found in the %s layout\n", current)
+		return nil
+	}
+
+	if migrateLayoutDryRun {
+		messagef("Dry run, no changes will be made\n")
+	}
+
+	for _, move := range moves {
+		messagef("• %s\n", move)
+	}
+
+	if migrateLayoutDryRun {
+		return nil
+	}
+
+	// Load both files before anything moves, so an unreadable one stops the migration
+	var registry *config.Registry
+	oldRegistryPath := filepath.Join(from.Data, "installation.json")
+	if _, err := os.Stat(oldRegistryPath); err == nil {
+		if registry, err = loadRegistry(oldRegistryPath); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadConfigForUpdate()
+	if err != nil {
+		return err
+	}
+
+	if err := layout.Apply(moves); err != nil {
+		return utils.NewUserError(
+			"Failed to move Binarius files",
+			err.Error(),
+			"Files that were already moved have been put back; fix the problem and try again",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	oldToolsDir := filepath.Join(from.Data, "tools")
+	newToolsDir := filepath.Join(to.Data, "tools")
+
+	if registry != nil && layout.RewriteRegistry(registry, oldToolsDir, newToolsDir) > 0 {
+		if err := config.SaveRegistry(registry, filepath.Join(to.Data, "installation.json")); err != nil {
+			return utils.NewUserError(
+				"Failed to update installation registry",
+				err.Error(),
+				fmt.Sprintf("Ensure %s is writable, then run 'binarius repair'", to.Data),
+			).WithCode(utils.CodeFilesystem)
+		}
+	}
+
+	newConfigPath := filepath.Join(to.Config, "config.yaml")
+	if _, err := os.Stat(newConfigPath); err == nil && layout.RewriteConfig(cfg, from) {
+		if err := config.Save(cfg, newConfigPath); err != nil {
+			return utils.NewUserError(
+				"Failed to update config.yaml",
+				err.Error(),
+				fmt.Sprintf("Remove the old directories from the 'paths' section of %s", newConfigPath),
+			).WithCode(utils.CodeFilesystem)
+		}
+	}
+
+	relinked, err := layout.Relink(binDir, oldToolsDir, newToolsDir)
+	if err != nil {
+		return utils.NewUserError(
+			"Failed to update symlinks",
+			err.Error(),
+			"Run 'binarius repair' to re-point symlinks at the default versions",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	// The lock file lives in the old home; drop it so the directory can go away
+	_ = homeLock.Release()
+	_ = os.Remove(filepath.Join(from.Data, lock.FileName))
+
+	oldDirs := []string{from.Config, from.Data}
+	if from.Cache != "" {
+		oldDirs = append(oldDirs, from.Cache)
+	}
+	remaining := layout.RemoveEmpty(oldDirs...)
+
+	messagef("\n✓ Moved %d item(s) to the %s layout and updated %d symlink(s)\n", len(moves), target, len(relinked))
+
+	for _, dir := range remaining {
+		noticef("⚠️  %s still contains files Binarius doesn't manage; review and remove it\n", dir)
+	}
+
+	if os.Getenv("BINARIUS_LAYOUT") != "" {
+		noticef("⚠️  BINARIUS_LAYOUT is set; change it to '%s' or unset it\n", target)
+	} else if target == paths.LayoutXDG && len(remaining) > 0 {
+		noticef("⚠️  Binarius keeps using the classic layout while ~/.binarius exists; set BINARIUS_LAYOUT=xdg\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/httpclient"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/spf13/cobra"
+)
+
+// outdatedFailOn is the smallest update that makes 'outdated' exit non-zero
+var outdatedFailOn string
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Show tools with newer versions available",
+	Long: `Compare the installed versions of every tool in the installation registry
+with the latest release upstream (or on the configured mirror).
+
+For each tool, the active version (or the newest installed version if none is
+active) is compared with the latest release, and the update is reported as
+patch, minor, or major. Pre-releases are ignored. Release APIs are queried
+concurrently.
+
+With --fail-on, the command exits with status 12 (E_UPDATES_AVAILABLE) if any
+tool has an update of at least that size, which is useful in CI. If a release
+API can't be reached, it exits with that error's status instead.
+
+Examples:
+  binarius outdated
+  binarius outdated --fail-on minor
+  binarius outdated --output json`,
+	Args: cobra.NoArgs,
+	RunE: runOutdated,
+}
+
+func init() {
+	outdatedCmd.Flags().StringVar(&outdatedFailOn, "fail-on", "", "Exit non-zero if any tool has an update of at least this size: patch, minor, or major")
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+func runOutdated(cmd *cobra.Command, args []string) error {
+	var failOn utils.Update
+	if outdatedFailOn != "" {
+		var err error
+		if failOn, err = utils.ParseUpdate(outdatedFailOn); err != nil {
+			return utils.NewUserError(
+				"Invalid --fail-on value",
+				err.Error(),
+				"Use --fail-on patch, --fail-on minor, or --fail-on major",
+			).WithCode(utils.CodeUsage)
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return err
+	}
+
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return err
+	}
+
+	toolNames := registry.ListTools()
+	sort.Strings(toolNames)
+
+	report := outdatedOutput{Tools: make([]outdatedTool, len(toolNames))}
+	failures := make([]error, len(toolNames))
+
+	var wg sync.WaitGroup
+	for i, toolName := range toolNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Tools[i], failures[i] = checkOutdated(cfg, registry, binDir, toolName)
+		}()
+	}
+	wg.Wait()
+
+	if structuredOutput() {
+		if err := writeOutput(report); err != nil {
+			return err
+		}
+	} else {
+		printOutdated(report)
+	}
+
+	// A tool that couldn't be checked may be outdated, so it fails the command
+	var failure error
+	for i, err := range failures {
+		if err == nil {
+			continue
+		}
+		if !structuredOutput() {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not check %s for updates: %v\n", toolNames[i], err)
+		}
+		if failure == nil {
+			failure = err
+		}
+	}
+	if failure != nil {
+		return &exitStatus{code: httpclient.ErrorCode(failure)}
+	}
+
+	if failOn != utils.UpdateNone {
+		for _, tool := range report.Tools {
+			if tool.Update.AtLeast(failOn) {
+				return &exitStatus{code: utils.CodeUpdatesAvailable}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkOutdated compares a tool's installed versions with its latest release.
+// The returned entry is filled in as far as possible even when err is set.
+func checkOutdated(cfg *config.Config, registry *config.Registry, binDir, toolName string) (outdatedTool, error) {
+	entry := outdatedTool{Tool: toolName, Active: activeVersion(binDir, toolName)}
+
+	for _, version := range registry.ListVersions(toolName) {
+		if registry.IsUsable(toolName, version) && utils.CompareVersions(version, entry.NewestInstalled) > 0 {
+			entry.NewestInstalled = version
+		}
+	}
+
+	tool, err := resolveTool(cfg, toolName)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry, err
+	}
+	versions, err := tool.ListVersions()
+	if err != nil {
+		entry.Error = err.Error()
+		return entry, err
+	}
+
+	entry.Latest = latestRelease(versions)
+	entry.LatestInstalled = registry.IsUsable(toolName, entry.Latest)
+
+	current := entry.Active
+	if current == "" {
+		current = entry.NewestInstalled
+	}
+	if current != "" && entry.Latest != "" {
+		entry.Update = utils.UpdateBetween(current, entry.Latest)
+	}
+
+	return entry, nil
+}
+
+// latestRelease returns the newest version that isn't a pre-release, or the
+// newest version if there are only pre-releases.
+func latestRelease(versions []string) string {
+	var latest, latestAny string
+	for _, version := range versions {
+		if utils.CompareVersions(version, latestAny) > 0 {
+			latestAny = version
+		}
+		if !utils.IsPrerelease(version) && utils.CompareVersions(version, latest) > 0 {
+			latest = version
+		}
+	}
+
+	if latest == "" {
+		return latestAny
+	}
+	return latest
+}
+
+// printOutdated prints the report as a table.
+func printOutdated(report outdatedOutput) {
+	if len(report.Tools) == 0 {
+		messagef("No tools installed\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOOL\tACTIVE\tINSTALLED\tLATEST\tUPDATE")
+
+	updates, unknown := 0, 0
+	for _, tool := range report.Tools {
+		update := string(tool.Update)
+		switch {
+		case tool.Error != "":
+			update = "unknown"
+			unknown++
+		case tool.Update == utils.UpdateNone:
+			update = "up to date"
+		case tool.LatestInstalled:
+			update += " (installed, run 'binarius use')"
+		}
+		if tool.Update != utils.UpdateNone {
+			updates++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			tool.Tool, orDash(tool.Active), orDash(tool.NewestInstalled), orDash(tool.Latest), update)
+	}
+	_ = w.Flush()
+
+	switch {
+	case updates > 0:
+		messagef("\n%d of %d tool(s) have updates available\n", updates, len(report.Tools))
+	case unknown == 0:
+		messagef("\n✓ All tools are up to date\n")
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
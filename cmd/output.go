@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output.
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// The types below are the --output json|yaml schemas. They are part of the
+// command line interface: add fields, but don't rename or remove them.
+
+// listOutput is the output of 'binarius list'.
+type listOutput struct {
+	Tools []listTool `json:"tools" yaml:"tools"`
+}
+
+type listTool struct {
+	Name     string        `json:"name" yaml:"name"`
+	Active   string        `json:"active" yaml:"active"` // Active version, "" if none
+	Versions []listVersion `json:"versions" yaml:"versions"`
+}
+
+type listVersion struct {
+	Version string `json:"version" yaml:"version"`
+	Status  string `json:"status" yaml:"status"` // "complete", "partial", or "broken"
+	Active  bool   `json:"active" yaml:"active"`
+}
+
+// infoOutput is the output of 'binarius info'.
+type infoOutput struct {
+	Tool          string             `json:"tool" yaml:"tool"`
+	ActiveVersion string             `json:"active_version" yaml:"active_version"`
+	Symlink       symlinkState       `json:"symlink" yaml:"symlink"`
+	BinaryExists  bool               `json:"binary_exists" yaml:"binary_exists"`
+	Installation  config.ToolVersion `json:"installation" yaml:"installation"`
+}
+
+type symlinkState struct {
+	Path   string `json:"path" yaml:"path"`
+	Target string `json:"target" yaml:"target"`
+	Valid  bool   `json:"valid" yaml:"valid"` // Target is the binary recorded in the registry
+}
+
+// installOutput is the output of 'binarius install'.
+type installOutput struct {
+	AlreadyInstalled bool               `json:"already_installed" yaml:"already_installed"`
+	Installation     config.ToolVersion `json:"installation" yaml:"installation"`
+}
+
+// installBatchOutput is the output of 'binarius install' with several versions.
+type installBatchOutput struct {
+	Installs []installResult `json:"installs" yaml:"installs"`
+}
+
+type installResult struct {
+	Spec             string              `json:"spec" yaml:"spec"` // <tool>@<version>, with "latest" resolved
+	AlreadyInstalled bool                `json:"already_installed" yaml:"already_installed"`
+	Installation     *config.ToolVersion `json:"installation,omitempty" yaml:"installation,omitempty"` // Unset if Error is set
+	Error            string              `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// outdatedOutput is the output of 'binarius outdated'.
+type outdatedOutput struct {
+	Tools []outdatedTool `json:"tools" yaml:"tools"`
+}
+
+type outdatedTool struct {
+	Tool            string `json:"tool" yaml:"tool"`
+	Active          string `json:"active" yaml:"active"`                     // "" if none
+	NewestInstalled string `json:"newest_installed" yaml:"newest_installed"` // Newest complete installation
+	Latest          string `json:"latest" yaml:"latest"`                     // Latest upstream release, "" if unknown
+	// Update from the active version (or the newest installed if none is
+	// active) to Latest: "patch", "minor", "major", or "" if up to date
+	Update          utils.Update `json:"update" yaml:"update"`
+	LatestInstalled bool         `json:"latest_installed" yaml:"latest_installed"`
+	Error           string       `json:"error,omitempty" yaml:"error,omitempty"` // Why Latest is unknown
+}
+
+// upgradeOutput is the output of 'binarius upgrade'.
+type upgradeOutput struct {
+	Upgrades []upgradeResult `json:"upgrades" yaml:"upgrades"`
+}
+
+type upgradeResult struct {
+	Tool      string `json:"tool" yaml:"tool"`
+	From      string `json:"from" yaml:"from"`
+	To        string `json:"to" yaml:"to"`               // Same as From if already up to date
+	Upgraded  bool   `json:"upgraded" yaml:"upgraded"`   // To was installed
+	Activated bool   `json:"activated" yaml:"activated"` // The symlink was switched to To
+	Removed   bool   `json:"removed" yaml:"removed"`     // From was uninstalled (--remove-old)
+}
+
+// pruneOutput is the output of 'binarius prune'.
+type pruneOutput struct {
+	DryRun         bool               `json:"dry_run" yaml:"dry_run"`
+	Removed        []prunedVersion    `json:"removed" yaml:"removed"`     // Versions removed, or that would be with --dry-run
+	Protected      []protectedVersion `json:"protected" yaml:"protected"` // Versions kept beyond --keep, and why
+	ReclaimedBytes int64              `json:"reclaimed_bytes" yaml:"reclaimed_bytes"`
+}
+
+type prunedVersion struct {
+	Tool      string `json:"tool" yaml:"tool"`
+	Version   string `json:"version" yaml:"version"`
+	Path      string `json:"path" yaml:"path"`
+	SizeBytes int64  `json:"size_bytes" yaml:"size_bytes"`
+}
+
+type protectedVersion struct {
+	Tool    string `json:"tool" yaml:"tool"`
+	Version string `json:"version" yaml:"version"`
+	Reason  string `json:"reason" yaml:"reason"` // e.g. "active" or "pinned in <file>"
+}
+
+// errorOutput is the output of any command that fails.
+type errorOutput struct {
+	Error errorDetail `json:"error" yaml:"error"`
+}
+
+type errorDetail struct {
+	Code     utils.Code `json:"code" yaml:"code"`           // e.g. "E_NOT_INSTALLED"
+	ExitCode int        `json:"exit_code" yaml:"exit_code"` // Process exit status
+	Message  string     `json:"message" yaml:"message"`     // What failed
+	Reason   string     `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Action   string     `json:"action,omitempty" yaml:"action,omitempty"`
+}
+
+// newErrorOutput describes an error for --output json|yaml.
+func newErrorOutput(err error, code utils.Code) errorOutput {
+	detail := errorDetail{Code: code, ExitCode: code.ExitCode(), Message: err.Error()}
+
+	var userErr *utils.UserError
+	if errors.As(err, &userErr) {
+		detail.Message = userErr.Context
+		detail.Reason = userErr.Reason
+		detail.Action = userErr.Action
+	}
+
+	return errorOutput{Error: detail}
+}
+
+// checkOutputFlag validates --output.
+func checkOutputFlag() error {
+	switch outputFlag {
+	case outputText, outputJSON, outputYAML:
+		return nil
+	default:
+		return utils.NewUserError(
+			"Invalid output format",
+			fmt.Sprintf("--output must be text, json, or yaml, got %q", outputFlag),
+			"Use --output json or --output yaml for machine-readable output",
+		).WithCode(utils.CodeUsage)
+	}
+}
+
+// structuredOutput reports whether --output selects JSON or YAML.
+func structuredOutput() bool {
+	return outputFlag == outputJSON || outputFlag == outputYAML
+}
+
+// writeOutput writes v to stdout in the format selected by --output.
+func writeOutput(v interface{}) error {
+	return encodeOutput(os.Stdout, outputFlag, v)
+}
+
+func encodeOutput(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case outputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+}
+
+// messagef prints a progress message for people. With --output json or yaml
+// it goes to stderr, so stdout only carries the structured output. With
+// --quiet it isn't printed at all.
+func messagef(format string, a ...interface{}) {
+	if quietFlag {
+		return
+	}
+	w := os.Stdout
+	if structuredOutput() {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format, a...)
+}
+
+// noticef prints a message for people about something other than the
+// command's result, such as waiting for a lock. It always goes to stderr, so
+// it never mixes with the output of a command, and isn't printed with --quiet.
+func noticef(format string, a ...interface{}) {
+	if quietFlag {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format, a...)
+}
+
+// installationOutput returns a registry entry for output, with the status
+// of entries written before statuses were recorded filled in.
+func installationOutput(tv config.ToolVersion) config.ToolVersion {
+	tv.Status = tv.StatusOrDefault()
+	return tv
+}
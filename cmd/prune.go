@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/nixknight/binarius/pkg/prune"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneKeep   int
+	pruneDryRun bool
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune [tool]",
+	Short: "Remove all but the newest installed versions",
+	Long: `Uninstall older versions of a tool, keeping the --keep newest versions by
+semantic version order. Without a tool name, every installed tool is pruned.
+
+These versions are never removed, even if they are older than the --keep newest:
+  - The active version
+  - The default version in config.yaml
+  - Versions pinned by project files: .<tool>-version (e.g. .terraform-version,
+    or .opentofu-version for tofu) or .tool-versions, in the current directory
+    or its parents, or up to 3 levels below the directories listed in
+    prune.project_dirs
+
+Examples:
+  binarius prune terraform --keep 3
+  binarius prune --dry-run
+  binarius config set prune.project_dirs ~/src,~/work`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeToolName,
+	RunE:              runPrune,
+}
+
+func init() {
+	pruneCmd.Flags().IntVar(&pruneKeep, "keep", 3, "Number of newest versions to keep per tool")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Show what would be removed without removing anything")
+	rootCmd.AddCommand(pruneCmd)
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	if pruneKeep < 1 {
+		return utils.NewUserError(
+			"Invalid --keep value",
+			fmt.Sprintf("--keep must be at least 1, got %d", pruneKeep),
+			"Use 'binarius uninstall' to remove every version of a tool",
+		).WithCode(utils.CodeUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+	toolsDir, err := paths.ToolsDir()
+	if err != nil {
+		return err
+	}
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return err
+	}
+
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return err
+	}
+
+	toolNames := registry.ListTools()
+	sort.Strings(toolNames)
+	if len(args) == 1 {
+		toolName := args[0]
+		if err := utils.ValidateToolName(toolName); err != nil {
+			return utils.NewUserError(
+				"Invalid tool name",
+				err.Error(),
+				"Tool name must be lowercase alphanumeric with hyphens only",
+			).WithCode(utils.CodeUsage)
+		}
+		if len(registry.ListVersions(toolName)) == 0 {
+			return utils.NewUserError(
+				fmt.Sprintf("%s is not installed", toolName),
+				"No versions found in registry",
+				"Run 'binarius list' to see installed tools",
+			).WithCode(utils.CodeNotInstalled)
+		}
+		toolNames = []string{toolName}
+	}
+
+	pins, err := projectPins(cfg, toolNames)
+	if err != nil {
+		return err
+	}
+
+	report := pruneOutput{DryRun: pruneDryRun, Removed: []prunedVersion{}, Protected: []protectedVersion{}}
+	for _, toolName := range toolNames {
+		reasons := protectedVersions(cfg, binDir, toolName, pins)
+		protected := make(map[string]bool, len(reasons))
+		for version := range reasons {
+			protected[version] = true
+		}
+
+		versions := registry.ListVersions(toolName)
+		remove := prune.Plan(versions, pruneKeep, protected)
+
+		// Report the protected versions that --keep alone would have removed
+		for _, version := range prune.Plan(versions, pruneKeep, nil) {
+			if reason, ok := reasons[version]; ok {
+				report.Protected = append(report.Protected, protectedVersion{Tool: toolName, Version: version, Reason: reason})
+			}
+		}
+
+		for _, version := range remove {
+			versionDir := filepath.Join(toolsDir, toolName, version)
+			size, err := prune.DirSize(versionDir)
+			if err != nil && !os.IsNotExist(err) {
+				return utils.NewUserError(
+					fmt.Sprintf("Failed to read version directory: %s", versionDir),
+					err.Error(),
+					"Ensure you have read permissions for ~/.binarius",
+				).WithCode(utils.CodeFilesystem)
+			}
+			report.Removed = append(report.Removed, prunedVersion{Tool: toolName, Version: version, Path: versionDir, SizeBytes: size})
+		}
+	}
+
+	if !pruneDryRun && len(report.Removed) > 0 {
+		if err := removePruned(&report, registryPath, toolsDir, binDir); err != nil {
+			return err
+		}
+	}
+
+	for _, version := range report.Removed {
+		report.ReclaimedBytes += version.SizeBytes
+	}
+
+	if structuredOutput() {
+		return writeOutput(report)
+	}
+	printPrune(report)
+	return nil
+}
+
+// projectPins returns the versions pinned by version files in the current
+// directory and its parents, and below the directories in prune.project_dirs.
+func projectPins(cfg *config.Config, toolNames []string) ([]prune.Pin, error) {
+	var dirs []string
+	for _, dir := range cfg.Prune.ProjectDirs {
+		expanded, err := paths.Expand(dir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, expanded)
+	}
+
+	pins := prune.FindPins(dirs, toolNames)
+	if cwd, err := os.Getwd(); err == nil {
+		pins = append(pins, prune.FindPinsUpward(cwd, toolNames)...)
+	}
+	return pins, nil
+}
+
+// protectedVersions returns the versions of a tool that prune must keep,
+// mapped to the reason they are kept.
+func protectedVersions(cfg *config.Config, binDir, toolName string, pins []prune.Pin) map[string]string {
+	reasons := make(map[string]string)
+	for _, pin := range pins {
+		if pin.Tool == toolName {
+			reasons[pin.Version] = fmt.Sprintf("pinned in %s", pin.File)
+		}
+	}
+	if version := cfg.GetDefault(toolName); version != "" {
+		reasons[version] = "default in config.yaml"
+	}
+	if version := activeVersion(binDir, toolName); version != "" {
+		reasons[version] = "active"
+	}
+	return reasons
+}
+
+// removePruned deletes the planned versions and saves the registry once, then
+// removes the directories of tools that have no versions left.
+// Versions that were removed or activated by another process in the meantime
+// are dropped from the report.
+func removePruned(report *pruneOutput, registryPath, toolsDir, binDir string) error {
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	// Reload the registry under the lock; another process may have changed it
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return err
+	}
+
+	removed := []prunedVersion{}
+	var removeErr error
+	for _, version := range report.Removed {
+		if !registry.IsInstalled(version.Tool, version.Version) || activeVersion(binDir, version.Tool) == version.Version {
+			continue
+		}
+		if err := os.RemoveAll(version.Path); err != nil {
+			removeErr = utils.NewUserError(
+				fmt.Sprintf("Failed to remove version directory: %s", version.Path),
+				err.Error(),
+				"Ensure you have write permissions for ~/.binarius",
+			).WithCode(utils.CodeFilesystem)
+			break
+		}
+		registry.RemoveVersion(version.Tool, version.Version)
+		removed = append(removed, version)
+	}
+	report.Removed = removed
+
+	// Record what was removed even if a later removal failed
+	if len(removed) > 0 {
+		if err := config.SaveRegistry(registry, registryPath); err != nil {
+			return utils.NewUserError(
+				"Failed to update installation registry",
+				err.Error(),
+				"The files were removed but the registry was not updated; run 'binarius repair'",
+			).WithCode(utils.CodeFilesystem)
+		}
+	}
+
+	// Don't leave empty tools/<tool> directories behind
+	for _, version := range removed {
+		removeEmptyToolDir(toolsDir, version.Tool)
+	}
+
+	return removeErr
+}
+
+// printPrune prints what was (or would be) removed and reclaimed, unless
+// --quiet is set.
+func printPrune(report pruneOutput) {
+	for _, version := range report.Protected {
+		messagef("Keeping %s@%s (%s)\n", version.Tool, version.Version, version.Reason)
+	}
+
+	verb := "Removed"
+	if report.DryRun {
+		verb = "Would remove"
+	}
+	for _, version := range report.Removed {
+		messagef("%s %s@%s (%s)\n", verb, version.Tool, version.Version, formatBytes(version.SizeBytes))
+	}
+
+	if len(report.Protected) > 0 || len(report.Removed) > 0 {
+		messagef("\n")
+	}
+	switch {
+	case len(report.Removed) == 0:
+		messagef("✓ Nothing to prune (keeping the %d newest version(s) of each tool)\n", pruneKeep)
+	case report.DryRun:
+		messagef("Would remove %d version(s) and reclaim %s\n", len(report.Removed), formatBytes(report.ReclaimedBytes))
+	default:
+		messagef("✓ Removed %d version(s) and reclaimed %s\n", len(report.Removed), formatBytes(report.ReclaimedBytes))
+	}
+}
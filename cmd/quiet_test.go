@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestQuietPrintsNothingOnSuccess(t *testing.T) {
+	tmpDir := setupTestHome(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "config set", args: []string{"config", "set", "prune.project_dirs", filepath.Join(tmpDir, "src")}},
+		{name: "config validate", args: []string{"config", "validate"}},
+		{name: "config unset", args: []string{"config", "unset", "prune.project_dirs"}},
+		{name: "verify", args: []string{"verify"}},
+		{name: "repair", args: []string{"repair"}},
+		{name: "prune", args: []string{"prune"}},
+		{name: "migrate-layout", args: []string{"migrate-layout", "--dry-run"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() { quietFlag = false }()
+			stdout, stderr, err := runCommand(t, append(tt.args, "-q")...)
+			if err != nil {
+				t.Fatalf("binarius %v -q failed: %v\nstderr: %s", tt.args, err, stderr)
+			}
+			if stdout != "" {
+				t.Errorf("binarius %v -q printed to stdout: %q", tt.args, stdout)
+			}
+			if stderr != "" {
+				t.Errorf("binarius %v -q printed to stderr: %q", tt.args, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/doctor"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/nixknight/binarius/pkg/symlink"
+	"github.com/spf13/cobra"
+)
+
+var (
+	repairDryRun        bool
+	repairTrustExisting bool
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Reconcile the registry, installed files and symlinks",
+	Long: `Repair drift between installation.json, the tools directory and the bin directory.
+
+This will:
+  - Register version directories missing from installation.json, with
+    their size and architecture
+  - Remove registry entries whose files are gone
+  - Mark versions without a binary as partial
+  - Re-point symlinks to the default versions in config.yaml
+
+A registry that can't be parsed is backed up and rebuilt from disk.
+
+Binaries without a recorded checksum, such as those found on disk, can't be
+verified and are registered as partial. If you trust the files in the tools
+directory, --trust-existing records the checksum of each such executable and
+marks it complete, so it can be used without reinstalling.
+
+Examples:
+  binarius repair
+  binarius repair --dry-run
+  binarius repair --trust-existing`,
+	Args: cobra.NoArgs,
+	RunE: runRepair,
+}
+
+func init() {
+	repairCmd.Flags().BoolVar(&repairDryRun, "dry-run", false, "Show what would be repaired without changing anything")
+	repairCmd.Flags().BoolVar(&repairTrustExisting, "trust-existing", false, "Record the checksums of unverified binaries on disk and mark them complete")
+	rootCmd.AddCommand(repairCmd)
+}
+
+func runRepair(cmd *cobra.Command, args []string) error {
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return err
+	}
+
+	toolsDir, err := paths.ToolsDir()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	registry, err := config.LoadRegistry(registryPath)
+	if config.IsNewerSchema(err) {
+		// Rebuilding would throw away whatever the newer Binarius recorded
+		return newerSchemaError("installation.json", err)
+	}
+	corrupt := err != nil
+	if corrupt {
+		noticef("⚠️  installation.json can't be parsed (%v); rebuilding it from %s\n", err, toolsDir)
+		registry = config.NewRegistry()
+	}
+
+	changes, err := doctor.Repair(registry, toolsDir, repairTrustExisting)
+	if err != nil {
+		return utils.NewUserError(
+			"Failed to repair installation registry",
+			err.Error(),
+			fmt.Sprintf("Ensure %s is readable", toolsDir),
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	if repairDryRun {
+		messagef("Dry run, no changes will be made\n")
+	}
+
+	for _, change := range changes {
+		messagef("• %s\n", change)
+	}
+
+	if !repairDryRun && (len(changes) > 0 || corrupt) {
+		if corrupt {
+			backupPath := fmt.Sprintf("%s.corrupt-%s", registryPath, time.Now().Format("20060102150405"))
+			if err := os.Rename(registryPath, backupPath); err != nil {
+				return utils.NewUserError(
+					"Failed to back up corrupt installation registry",
+					err.Error(),
+					fmt.Sprintf("Move %s out of the way manually and run 'binarius repair' again", registryPath),
+				).WithCode(utils.CodeFilesystem)
+			}
+			messagef("Backed up corrupt registry to %s\n", backupPath)
+		}
+
+		if err := config.SaveRegistry(registry, registryPath); err != nil {
+			return utils.NewUserError(
+				"Failed to update installation registry",
+				err.Error(),
+				"Ensure ~/.binarius is writable",
+			).WithCode(utils.CodeFilesystem)
+		}
+	}
+
+	// Re-point symlinks at the default versions
+	toolNames := make([]string, 0, len(cfg.Defaults))
+	for toolName := range cfg.Defaults {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	manager := &symlink.Manager{}
+	relinked := 0
+	for _, toolName := range toolNames {
+		version := cfg.Defaults[toolName]
+		if !registry.IsInstalled(toolName, version) {
+			noticef("⚠️  Default %s@%s is not installed; run 'binarius install %s@%s'\n", toolName, version, toolName, version)
+			continue
+		}
+
+		tv := registry.GetVersion(toolName, version)
+		if !tv.IsComplete() {
+			noticef("⚠️  Default %s@%s is %s; reinstall it to restore the symlink\n", toolName, version, tv.StatusOrDefault())
+			continue
+		}
+
+		symlinkPath := filepath.Join(binDir, toolName)
+		if err := manager.Verify(symlinkPath, tv.BinaryPath); err == nil {
+			continue
+		}
+
+		if repairDryRun {
+			messagef("• %s would point to %s@%s\n", symlinkPath, toolName, version)
+			relinked++
+			continue
+		}
+
+		if err := manager.Update(tv.BinaryPath, symlinkPath); err != nil {
+			return utils.NewUserError(
+				fmt.Sprintf("Failed to update symlink at %s", symlinkPath),
+				err.Error(),
+				fmt.Sprintf("Ensure %s exists and is writable", binDir),
+			).WithCode(utils.CodeFilesystem)
+		}
+		messagef("• %s now points to %s@%s\n", symlinkPath, toolName, version)
+		relinked++
+	}
+
+	switch {
+	case len(changes) == 0 && relinked == 0 && !corrupt:
+		messagef("✓ Nothing to repair\n")
+	case !repairDryRun:
+		messagef("\n✓ Made %d registry change(s) and updated %d symlink(s)\n", len(changes), relinked)
+	}
+
+	if unverified := countUnverified(registry); unverified > 0 && !repairTrustExisting {
+		noticef("\n⚠️  %d version(s) can't be verified and stay partial; reinstall them, or run 'binarius repair --trust-existing' if you trust the files in %s\n", unverified, toolsDir)
+	}
+
+	return nil
+}
+
+// countUnverified returns the number of partial versions whose binary is on
+// disk but has no recorded checksum.
+func countUnverified(registry *config.Registry) int {
+	count := 0
+	for _, toolName := range registry.ListTools() {
+		for _, version := range registry.ListVersions(toolName) {
+			tv := registry.GetVersion(toolName, version)
+			if tv.Status != config.StatusPartial || tv.BinaryChecksum != "" {
+				continue
+			}
+			if info, err := os.Stat(tv.BinaryPath); err == nil && info.Mode().IsRegular() {
+				count++
+			}
+		}
+	}
+	return count
+}
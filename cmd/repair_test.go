@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRepairRebuildsUsableRegistry corrupts the registry, rebuilds it with
+// 'repair --trust-existing', and checks that the version on disk can be used.
+func TestRepairRebuildsUsableRegistry(t *testing.T) {
+	tmpDir := setupTestHome(t)
+	defer func() { repairTrustExisting = false }()
+
+	home := filepath.Join(tmpDir, ".binarius")
+	versionDir := filepath.Join(home, "tools", "terraform", "v1.6.0")
+	for _, dir := range []string{versionDir, filepath.Join(tmpDir, "bin")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	binary := filepath.Join(versionDir, "terraform")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "installation.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, stderr, err := runCommand(t, "repair", "--trust-existing"); err != nil {
+		t.Fatalf("binarius repair --trust-existing failed: %v\nstderr: %s", err, stderr)
+	}
+
+	if _, stderr, err := runCommand(t, "use", "terraform@v1.6.0"); err != nil {
+		t.Fatalf("binarius use failed after repair: %v\nstderr: %s", err, stderr)
+	}
+
+	target, err := os.Readlink(filepath.Join(tmpDir, "bin", "terraform"))
+	if err != nil {
+		t.Fatalf("use didn't create the symlink: %v", err)
+	}
+	if target != binary {
+		t.Errorf("symlink points to %s, want %s", target, binary)
+	}
+}
@@ -1,11 +1,34 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/paths"
 	"github.com/spf13/cobra"
 )
 
+// Global directory flags, which take precedence over environment variables and config.yaml
+var (
+	homeFlag     string
+	binDirFlag   string
+	cacheDirFlag string
+)
+
+// outputFlag selects the output format: text, json, or yaml
+var outputFlag string
+
+// Logging flags: -v shows info logs, -vv debug logs, and --quiet hides
+// progress messages and everything but errors
+var (
+	verboseFlag int
+	quietFlag   bool
+	logFileFlag string
+)
+
 // Version information (set from main.go)
 var (
 	Version   string
@@ -24,6 +47,59 @@ allowing you to install, switch between, and manage multiple versions of
 any single-binary CLI tool.
 
 Currently supports: terraform, opentofu (tofu), and terragrunt.`,
+	PersistentPreRunE: initSettings,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&homeFlag, "home", "", "Binarius home directory (default ~/.binarius, or $BINARIUS_HOME)")
+	rootCmd.PersistentFlags().StringVar(&binDirFlag, "bin-dir", "", "Directory for tool symlinks (default ~/.local/bin, or $BINARIUS_BIN_DIR)")
+	rootCmd.PersistentFlags().StringVar(&cacheDirFlag, "cache-dir", "", "Directory for downloaded archives (default <home>/cache, or $BINARIUS_CACHE_DIR)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", outputText, "Output format: text, json, or yaml")
+	rootCmd.PersistentFlags().CountVarP(&verboseFlag, "verbose", "v", "Log what Binarius is doing (-v for info, -vv for debug)")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Print errors only")
+	rootCmd.PersistentFlags().StringVar(&logFileFlag, "log-file", "", "Append debug logs as JSON to this file, e.g. for bug reports")
+}
+
+// initSettings applies settings that affect every command: the global flags,
+// directory paths from config.yaml, and the TLS configuration of the shared
+// HTTP client.
+func initSettings(cmd *cobra.Command, args []string) error {
+	if err := applyGlobalFlags(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	return configureHTTP(cfg)
+}
+
+// applyGlobalFlags checks the global flags, sets up logging, and hands the
+// directory flags to pkg/paths.
+func applyGlobalFlags() error {
+	if err := checkOutputFlag(); err != nil {
+		return err
+	}
+	if err := configureLogging(); err != nil {
+		return err
+	}
+
+	err := paths.SetFlags(paths.Overrides{
+		Home:     homeFlag,
+		BinDir:   binDirFlag,
+		CacheDir: cacheDirFlag,
+	})
+	if err != nil {
+		return utils.NewUserError(
+			"Invalid directory flag",
+			err.Error(),
+			"Pass an absolute path or a path relative to the current directory to --home, --bin-dir, or --cache-dir",
+		).WithCode(utils.CodeUsage)
+	}
+
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -36,5 +112,102 @@ Build Date: %s
 Git Commit: %s
 `, Version, BuildDate, GitCommit))
 
-	return rootCmd.Execute()
+	// Errors (and the usage that follows them) are reported by reportError,
+	// in the format selected by --output
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err}
+	})
+	markUsageErrors(rootCmd)
+
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		slog.Debug("Command failed", "command", cmd.CommandPath(), "code", errorCode(err), "error", err.Error())
+		reportError(cmd, err)
+	}
+	closeLogFile()
+	return err
+}
+
+// ExitCode returns the process exit status for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return errorCode(err).ExitCode()
+}
+
+// usageError marks errors about the command line itself, such as unknown
+// flags or a wrong number of arguments.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// markUsageErrors wraps the argument validation of every command so its
+// errors are reported as usage errors.
+func markUsageErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return &usageError{err}
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		markUsageErrors(child)
+	}
+}
+
+// exitStatus is returned by commands that have already printed their
+// result, such as a report, and only need to exit with a non-zero status.
+type exitStatus struct {
+	code utils.Code
+}
+
+func (e *exitStatus) Error() string { return string(e.code) }
+
+// errorCode returns the code of an error returned by a command.
+func errorCode(err error) utils.Code {
+	var usageErr *usageError
+	if errors.As(err, &usageErr) || isUnknownCommand(err) {
+		return utils.CodeUsage
+	}
+	var status *exitStatus
+	if errors.As(err, &status) {
+		return status.code
+	}
+	return utils.CodeOf(err)
+}
+
+// isUnknownCommand reports whether cobra failed to find the command to run,
+// which happens before any of our code runs.
+func isUnknownCommand(err error) bool {
+	return strings.HasPrefix(err.Error(), "unknown command")
+}
+
+// reportError prints an error returned by cmd: as JSON or YAML on stdout with
+// --output json|yaml, otherwise as text on stderr followed by the command's usage.
+// Nothing is printed for an exitStatus; the command has reported its result.
+func reportError(cmd *cobra.Command, err error) {
+	var status *exitStatus
+	if errors.As(err, &status) {
+		return
+	}
+
+	if structuredOutput() {
+		_ = writeOutput(newErrorOutput(err, errorCode(err)))
+		return
+	}
+
+	cmd.PrintErrln(cmd.ErrPrefix(), err.Error())
+	if isUnknownCommand(err) {
+		cmd.PrintErrf("Run '%v --help' for usage.\n", cmd.CommandPath())
+		return
+	}
+	cmd.PrintErrln(cmd.UsageString())
 }
@@ -19,20 +19,25 @@ var (
 )
 
 var uninstallCmd = &cobra.Command{
-	Use:   "uninstall <tool>@<version>",
-	Short: "Uninstall a tool version",
-	Long: `Uninstall a specific version of a tool.
+	Use:   "uninstall <tool>@<version>...",
+	Short: "Uninstall tool versions",
+	Long: `Uninstall specific versions of tools.
 
 This will:
   - Remove the tool binary files
   - Update the installation registry
   - Warn if attempting to uninstall the active version
 
+With several versions, all of them must be installed, a single confirmation
+covers them all, and the installation registry is saved once.
+
 Examples:
   binarius uninstall terraform@v1.5.0
-  binarius uninstall tofu@v1.6.0 --force`,
-	Args: cobra.ExactArgs(1),
-	RunE: runUninstall,
+  binarius uninstall tofu@v1.6.0 --force
+  binarius uninstall terraform@v1.4.0 terraform@v1.5.0 tofu@v1.6.0`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeToolVersions(installedVersions),
+	RunE:              runUninstall,
 }
 
 func init() {
@@ -41,109 +46,67 @@ func init() {
 }
 
 func runUninstall(cmd *cobra.Command, args []string) error {
-	// Parse tool@version
-	parts := strings.Split(args[0], "@")
-	if len(parts) != 2 {
-		return utils.NewUserError(
-			"Invalid argument format",
-			fmt.Sprintf("Expected format: <tool>@<version>, got: %s", args[0]),
-			"Use format like 'terraform@v1.6.0'",
-		)
-	}
-
-	toolName := parts[0]
-	version := parts[1]
-
-	// Validate tool name
-	if err := utils.ValidateToolName(toolName); err != nil {
-		return utils.NewUserError(
-			"Invalid tool name",
-			err.Error(),
-			"Tool name must be lowercase alphanumeric with hyphens only",
-		)
-	}
-
-	// Normalize version (ensure 'v' prefix)
-	version, err := utils.NormalizeVersion(version)
-	if err != nil {
-		return utils.NewUserError(
-			"Invalid version format",
-			err.Error(),
-			"Version must follow semantic versioning (e.g., v1.6.0, 1.6.0-beta1)",
-		)
-	}
-
-	// Get paths
-	binariusHome, err := paths.BinariusHome()
+	specs, err := parseSpecs(args, false)
 	if err != nil {
 		return err
 	}
 
-	registryPath := filepath.Join(binariusHome, "installation.json")
-	toolsDir, err := paths.ToolsDir()
+	// Get paths
+	registryPath, err := paths.RegistryFile()
 	if err != nil {
 		return err
 	}
-
 	binDir, err := paths.BinDir()
 	if err != nil {
 		return err
 	}
 
 	// Load registry
-	registry, err := config.LoadRegistry(registryPath)
+	registry, err := loadRegistry(registryPath)
 	if err != nil {
-		return utils.NewUserError(
-			"Failed to load installation registry",
-			err.Error(),
-			"Run 'binarius init' to initialize Binarius",
-		)
+		return err
 	}
 
-	// Check if version is installed
-	if !registry.IsInstalled(toolName, version) {
-		return utils.NewUserError(
-			fmt.Sprintf("%s@%s is not installed", toolName, version),
-			"Version not found in registry",
-			fmt.Sprintf("Run 'binarius list %s' to see installed versions", toolName),
-		)
+	// Check that every version is installed before removing any of them
+	targets := make([]uninstallTarget, len(specs))
+	for i, spec := range specs {
+		if !registry.IsInstalled(spec.Tool, spec.Version) {
+			return utils.NewUserError(
+				fmt.Sprintf("%s is not installed", spec),
+				"Version not found in registry",
+				fmt.Sprintf("Run 'binarius list %s' to see installed versions", spec.Tool),
+			).WithCode(utils.CodeNotInstalled)
+		}
+		targets[i] = uninstallTarget{toolSpec: spec, isActive: activeVersion(binDir, spec.Tool) == spec.Version}
 	}
 
-	// Check if this is the active version
-	isActive := false
-	symlinkPath := filepath.Join(binDir, toolName)
-	if target, err := os.Readlink(symlinkPath); err == nil {
-		// Extract version from symlink target
-		targetParts := strings.Split(target, string(filepath.Separator))
-		for i, part := range targetParts {
-			if part == toolName && i+1 < len(targetParts) {
-				if targetParts[i+1] == version {
-					isActive = true
-				}
-				break
-			}
+	// Warn about active versions
+	warned := false
+	for _, target := range targets {
+		if target.isActive {
+			noticef("⚠️  WARNING: %s is currently the active version\n", target)
+			noticef("Uninstalling it will remove the symlink.\n")
+			warned = true
 		}
 	}
-
-	// Warn if active version
-	if isActive {
-		fmt.Printf("⚠️  WARNING: %s@%s is currently the active version\n", toolName, version)
-		fmt.Println("Uninstalling it will remove the symlink.")
-		fmt.Println()
+	if warned {
+		noticef("\n")
 	}
 
-	// Get version metadata for display
-	toolVersion := registry.GetVersion(toolName, version)
-
-	// Confirmation prompt unless --force
+	// One confirmation prompt for all versions unless --force. The prompt goes
+	// to stderr, even with --quiet, so it is never lost or mixed with output.
 	if !forceUninstall {
-		fmt.Printf("You are about to uninstall:\n")
-		fmt.Printf("  Tool: %s\n", toolName)
-		fmt.Printf("  Version: %s\n", version)
-		fmt.Printf("  Binary: %s\n", toolVersion.BinaryPath)
-		fmt.Println()
+		fmt.Fprintf(os.Stderr, "You are about to uninstall:\n")
+		for _, target := range targets {
+			// Get version metadata for display
+			toolVersion := registry.GetVersion(target.Tool, target.Version)
+			fmt.Fprintf(os.Stderr, "  Tool: %s\n", target.Tool)
+			fmt.Fprintf(os.Stderr, "  Version: %s\n", target.Version)
+			fmt.Fprintf(os.Stderr, "  Binary: %s\n", toolVersion.BinaryPath)
+			fmt.Fprintln(os.Stderr)
+		}
 
-		fmt.Print("Are you sure you want to continue? [y/N]: ")
+		fmt.Fprint(os.Stderr, "Are you sure you want to continue? [y/N]: ")
 		reader := bufio.NewReader(os.Stdin)
 		response, err := reader.ReadString('\n')
 		if err != nil {
@@ -156,71 +119,159 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 
 		response = strings.ToLower(strings.TrimSpace(response))
 		if response != "y" && response != "yes" {
-			fmt.Println("Uninstall cancelled")
+			messagef("Uninstall cancelled\n")
 			return nil
 		}
 	}
 
-	// Remove version directory
-	versionDir := filepath.Join(toolsDir, toolName, version)
-	if err := os.RemoveAll(versionDir); err != nil {
-		return utils.NewUserError(
-			fmt.Sprintf("Failed to remove version directory: %s", versionDir),
-			err.Error(),
-			"Ensure you have write permissions for ~/.binarius",
-		)
+	return removeVersions(targets)
+}
+
+// uninstallTarget is a version to uninstall.
+type uninstallTarget struct {
+	toolSpec
+	isActive bool // The tool's symlink points to this version
+}
+
+// removeVersion deletes an installed version and its registry entry. If it is
+// the active version, its symlink is removed too.
+func removeVersion(toolName, version string, isActive bool) error {
+	return removeVersions([]uninstallTarget{{toolSpec: toolSpec{Tool: toolName, Version: version}, isActive: isActive}})
+}
+
+// removeVersions deletes installed versions and their registry entries, which
+// are saved once at the end. Symlinks of active versions are removed too. A
+// version that can't be removed doesn't stop the others.
+func removeVersions(targets []uninstallTarget) error {
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+	toolsDir, err := paths.ToolsDir()
+	if err != nil {
+		return err
+	}
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("✓ Removed files from %s\n", versionDir)
+	// Hold the home lock while removing files and updating the registry
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
 
-	// Update registry
-	registry.RemoveVersion(toolName, version)
-	if err := config.SaveRegistry(registry, registryPath); err != nil {
-		return utils.NewUserError(
-			"Failed to update installation registry",
-			err.Error(),
-			"The files were removed but the registry was not updated",
-		)
+	// Reload the registry under the lock; another process may have changed it
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("✓ Updated installation registry\n")
+	var removed []uninstallTarget
+	var failed []string
+	var firstErr error
+	for _, target := range targets {
+		if !registry.IsInstalled(target.Tool, target.Version) {
+			messagef("✓ %s was already uninstalled by another process\n", target)
+			continue
+		}
 
-	// Check if tool directory is now empty and remove it
-	toolDir := filepath.Join(toolsDir, toolName)
-	entries, err := os.ReadDir(toolDir)
-	if err == nil && len(entries) == 0 {
-		if err := os.Remove(toolDir); err == nil {
-			fmt.Printf("✓ Removed empty tool directory: %s\n", toolDir)
+		// Remove version directory
+		versionDir := filepath.Join(toolsDir, target.Tool, target.Version)
+		if err := os.RemoveAll(versionDir); err != nil {
+			err := utils.NewUserError(
+				fmt.Sprintf("Failed to remove version directory: %s", versionDir),
+				err.Error(),
+				"Ensure you have write permissions for ~/.binarius",
+			).WithCode(utils.CodeFilesystem)
+			if len(targets) == 1 {
+				return err
+			}
+			// Report the failure and carry on with the other versions
+			fmt.Fprintf(os.Stderr, "✗ Failed to uninstall %s:\n%s\n\n", target, err)
+			failed = append(failed, target.String())
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
+
+		messagef("✓ Removed files from %s\n", versionDir)
+		registry.RemoveVersion(target.Tool, target.Version)
+		removed = append(removed, target)
+	}
+
+	// Update registry
+	if len(removed) > 0 {
+		if err := config.SaveRegistry(registry, registryPath); err != nil {
+			return utils.NewUserError(
+				"Failed to update installation registry",
+				err.Error(),
+				"The files were removed but the registry was not updated",
+			).WithCode(utils.CodeFilesystem)
+		}
+
+		messagef("✓ Updated installation registry\n")
+	}
+
+	for _, target := range removed {
+		finishUninstall(registry, toolsDir, binDir, target)
+	}
+
+	if len(failed) > 0 {
+		return batchError("uninstall", "uninstall", "version", failed, len(targets), firstErr)
+	}
+	return nil
+}
+
+// finishUninstall removes the empty tool directory and the symlink of an
+// active version once a version has been removed, and explains what to do next.
+func finishUninstall(registry *config.Registry, toolsDir, binDir string, target uninstallTarget) {
+	toolName := target.Tool
+
+	if toolDir, ok := removeEmptyToolDir(toolsDir, toolName); ok {
+		messagef("✓ Removed empty tool directory: %s\n", toolDir)
 	}
 
 	// If this was the active version, remove the broken symlink
-	if isActive {
+	symlinkPath := filepath.Join(binDir, toolName)
+	if target.isActive {
 		manager := &symlink.Manager{}
 		if err := manager.Remove(symlinkPath); err != nil {
 			// Non-fatal - warn but don't fail uninstall
-			fmt.Printf("⚠️  Warning: Could not remove symlink at %s: %v\n", symlinkPath, err)
+			noticef("⚠️  Warning: Could not remove symlink at %s: %v\n", symlinkPath, err)
 		} else {
-			fmt.Printf("✓ Removed symlink at %s\n", symlinkPath)
+			messagef("✓ Removed symlink at %s\n", symlinkPath)
 		}
 	}
 
-	fmt.Printf("\n✓ Successfully uninstalled %s@%s\n", toolName, version)
+	messagef("\n✓ Successfully uninstalled %s\n", target)
 
 	// If this was the active version, provide guidance
-	if isActive {
+	if target.isActive {
 		remainingVersions := registry.ListVersions(toolName)
 		if len(remainingVersions) > 0 {
-			fmt.Printf("\nTo set a new active version, run:\n")
-			fmt.Printf("    binarius use %s@<version>\n", toolName)
-			fmt.Printf("\nAvailable versions:\n")
+			messagef("\nTo set a new active version, run:\n")
+			messagef("    binarius use %s@<version>\n", toolName)
+			messagef("\nAvailable versions:\n")
 			for _, v := range remainingVersions {
-				fmt.Printf("    %s\n", v)
+				messagef("    %s\n", v)
 			}
 		} else {
-			fmt.Printf("\nNo other versions of %s are installed.\n", toolName)
+			messagef("\nNo other versions of %s are installed.\n", toolName)
 		}
 	}
+}
 
-	return nil
+// removeEmptyToolDir removes a tool's directory once its last version is gone.
+// Returns the directory and whether it was removed.
+func removeEmptyToolDir(toolsDir, toolName string) (string, bool) {
+	toolDir := filepath.Join(toolsDir, toolName)
+	entries, err := os.ReadDir(toolDir)
+	if err != nil || len(entries) > 0 {
+		return toolDir, false
+	}
+	return toolDir, os.Remove(toolDir) == nil
 }
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/httpclient"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeWithin    string
+	upgradeAll       bool
+	upgradeRemoveOld bool
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [tool...]",
+	Short: "Upgrade tools to the newest compatible version",
+	Long: `Install the newest upstream release of a tool within an allowed range of
+updates, and switch to it.
+
+The version being upgraded is the active version, or the newest installed
+version if none is active. --within limits how far it may move:
+  patch   Same major.minor, e.g. v1.6.0 -> v1.6.5
+  minor   Same major, e.g. v1.6.0 -> v1.9.2 (default)
+  major   Any newer release
+
+If the old version was active, the symlink is switched to the new version.
+If the old version was the default in config.yaml, the default is updated.
+With --remove-old, the old version is uninstalled afterwards.
+Pre-releases are never selected.
+
+Examples:
+  binarius upgrade terraform
+  binarius upgrade terraform --within patch
+  binarius upgrade --all --within major --remove-old`,
+	ValidArgsFunction: completeToolNames,
+	RunE:              runUpgrade,
+}
+
+func init() {
+	upgradeCmd.Flags().StringVar(&upgradeWithin, "within", string(utils.UpdateMinor), "Largest update allowed: patch, minor, or major")
+	upgradeCmd.Flags().BoolVar(&upgradeAll, "all", false, "Upgrade every installed tool")
+	upgradeCmd.Flags().BoolVar(&upgradeRemoveOld, "remove-old", false, "Uninstall the version that was upgraded from")
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	within, err := utils.ParseUpdate(upgradeWithin)
+	if err != nil {
+		return utils.NewUserError(
+			"Invalid --within value",
+			err.Error(),
+			"Use --within patch, --within minor, or --within major",
+		).WithCode(utils.CodeUsage)
+	}
+
+	if upgradeAll == (len(args) > 0) {
+		return utils.NewUserError(
+			"Nothing to upgrade",
+			"Pass either tool names or --all",
+			"Run 'binarius upgrade <tool>' or 'binarius upgrade --all'",
+		).WithCode(utils.CodeUsage)
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return err
+	}
+
+	toolNames := args
+	if upgradeAll {
+		toolNames = registry.ListTools()
+		sort.Strings(toolNames)
+	}
+
+	for _, toolName := range toolNames {
+		if err := utils.ValidateToolName(toolName); err != nil {
+			return utils.NewUserError(
+				"Invalid tool name",
+				err.Error(),
+				"Tool name must be lowercase alphanumeric with hyphens only",
+			).WithCode(utils.CodeUsage)
+		}
+	}
+
+	report := upgradeOutput{Upgrades: []upgradeResult{}}
+	var failed []string
+	var firstErr error
+	for _, toolName := range toolNames {
+		result, err := upgradeTool(toolName, within)
+		if err != nil {
+			// Report the failure and carry on with the other tools
+			if len(toolNames) == 1 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "✗ Failed to upgrade %s:\n%s\n\n", toolName, err)
+			failed = append(failed, toolName)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		report.Upgrades = append(report.Upgrades, result)
+	}
+
+	if structuredOutput() {
+		if err := writeOutput(report); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) > 0 {
+		return batchError("upgrade", "upgrade", "tool", failed, len(toolNames), firstErr)
+	}
+
+	return nil
+}
+
+// upgradeTool installs the newest release of a tool within the allowed update
+// size, then switches to it and optionally removes the old version.
+func upgradeTool(toolName string, within utils.Update) (upgradeResult, error) {
+	result := upgradeResult{Tool: toolName}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return result, err
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return result, err
+	}
+	binDir, err := paths.BinDir()
+	if err != nil {
+		return result, err
+	}
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return result, err
+	}
+
+	// Upgrade from the active version, or the newest usable installation
+	active := activeVersion(binDir, toolName)
+	current := active
+	if current == "" {
+		for _, version := range registry.ListVersions(toolName) {
+			if registry.IsUsable(toolName, version) && utils.CompareVersions(version, current) > 0 {
+				current = version
+			}
+		}
+	}
+	if current == "" {
+		return result, utils.NewUserError(
+			fmt.Sprintf("%s is not installed", toolName),
+			"No usable installation to upgrade from",
+			fmt.Sprintf("Run 'binarius install %s@latest' to install it", toolName),
+		).WithCode(utils.CodeNotInstalled)
+	}
+	result.From = current
+
+	tool, err := resolveTool(cfg, toolName)
+	if err != nil {
+		return result, err
+	}
+
+	versions, err := tool.ListVersions()
+	if err != nil {
+		return result, utils.NewUserError(
+			"Failed to fetch available versions",
+			err.Error(),
+			"Check your internet connection and try again",
+		).WithCode(httpclient.ErrorCode(err))
+	}
+
+	target := newestWithin(current, versions, within)
+	if target == "" {
+		messagef("✓ %s %s is up to date (within %s updates)\n", toolName, current, within)
+		result.To = current
+		return result, nil
+	}
+	result.To = target
+
+	messagef("Upgrading %s %s -> %s\n", toolName, current, target)
+	if _, _, err := installVersion(cfg, tool, toolName, target); err != nil {
+		return result, err
+	}
+	result.Upgraded = true
+
+	switch {
+	case active == current:
+		// Switches the symlink and updates the default in config.yaml
+		if err := activateVersion(toolName, target); err != nil {
+			return result, err
+		}
+		result.Activated = true
+	case cfg.GetDefault(toolName) == current:
+		err := updateConfig(func(userCfg *config.Config) error {
+			userCfg.SetDefault(toolName, target)
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+		messagef("Updated default version in config.yaml\n")
+	}
+
+	if upgradeRemoveOld {
+		if err := removeVersion(toolName, current, false); err != nil {
+			return result, err
+		}
+		result.Removed = true
+	}
+
+	messagef("✓ Upgraded %s %s -> %s\n", toolName, current, target)
+	return result, nil
+}
+
+// newestWithin returns the newest release in versions that is newer than
+// current by at most the given update size, or "" if there is none.
+// Pre-releases are skipped.
+func newestWithin(current string, versions []string, within utils.Update) string {
+	var newest string
+	for _, version := range versions {
+		if utils.IsPrerelease(version) {
+			continue
+		}
+		update := utils.UpdateBetween(current, version)
+		if update == utils.UpdateNone || !within.AtLeast(update) {
+			continue
+		}
+		if utils.CompareVersions(version, newest) > 0 {
+			newest = version
+		}
+	}
+	return newest
+}
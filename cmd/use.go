@@ -1,31 +1,37 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/nixknight/binarius/internal/utils"
 	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/installer"
 	"github.com/nixknight/binarius/pkg/paths"
 	"github.com/nixknight/binarius/pkg/symlink"
 	"github.com/spf13/cobra"
 )
 
 var useCmd = &cobra.Command{
-	Use:   "use <tool>@<version>",
-	Short: "Activate a tool version",
-	Long: `Activate a specific version of a tool by creating/updating a symlink.
+	Use:   "use <tool>@<version>...",
+	Short: "Activate tool versions",
+	Long: `Activate specific versions of tools by creating/updating symlinks.
 
 This makes the specified version the active version by creating a symlink:
   ~/.local/bin/<tool> -> ~/.binarius/tools/<tool>/<version>/<binary>
 
+With several versions, each is activated in turn; a version that fails
+doesn't stop the others.
+
 Examples:
   binarius use terraform@v1.6.0
   binarius use tofu@v1.5.0
-  binarius use terragrunt@v0.54.0`,
-	Args: cobra.ExactArgs(1),
-	RunE: runUse,
+  binarius use terraform@v1.6.0 tofu@v1.6.2 terragrunt@v0.54.0`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeToolVersions(installedVersions),
+	RunE:              runUse,
 }
 
 func init() {
@@ -33,60 +39,78 @@ func init() {
 }
 
 func runUse(cmd *cobra.Command, args []string) error {
-	// Parse tool@version
-	parts := strings.Split(args[0], "@")
-	if len(parts) != 2 {
-		return utils.NewUserError(
-			"Invalid argument format",
-			fmt.Sprintf("Expected format: <tool>@<version>, got: %s", args[0]),
-			"Use format like 'terraform@v1.6.0'",
-		)
+	specs, err := parseSpecs(args, false)
+	if err != nil {
+		return err
 	}
 
-	toolName := parts[0]
-	version := parts[1]
-
-	// Validate tool name
-	if err := utils.ValidateToolName(toolName); err != nil {
-		return utils.NewUserError(
-			"Invalid tool name",
-			err.Error(),
-			"Tool name must be lowercase alphanumeric with hyphens only",
-		)
+	var failed []string
+	var firstErr error
+	for _, spec := range specs {
+		if err := activateVersion(spec.Tool, spec.Version); err != nil {
+			// Report the failure and carry on with the other versions
+			if len(specs) == 1 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "✗ Failed to activate %s:\n%s\n\n", spec, err)
+			failed = append(failed, spec.String())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
 	}
 
-	// Normalize version (ensure 'v' prefix)
-	version, err := utils.NormalizeVersion(version)
-	if err != nil {
-		return utils.NewUserError(
-			"Invalid version format",
-			err.Error(),
-			"Version must follow semantic versioning (e.g., v1.6.0, 1.6.0-beta1)",
-		)
+	if len(failed) > 0 {
+		return batchError("use", "activate", "version", failed, len(specs), firstErr)
 	}
+	return nil
+}
 
+// activateVersion points the tool's symlink at an installed version and makes
+// it the default in config.yaml. version must be normalized.
+func activateVersion(toolName, version string) error {
 	// Get paths
-	binariusHome, err := paths.BinariusHome()
+	registryPath, err := paths.RegistryFile()
 	if err != nil {
 		return err
 	}
 
-	registryPath := filepath.Join(binariusHome, "installation.json")
-	configPath := filepath.Join(binariusHome, "config.yaml")
+	configPath, err := paths.ConfigFile()
+	if err != nil {
+		return err
+	}
 
 	binDir, err := paths.BinDir()
 	if err != nil {
 		return err
 	}
 
+	// Hold the home lock while switching the symlink and updating config.yaml
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
 	// Load registry
-	registry, err := config.LoadRegistry(registryPath)
+	registry, err := loadRegistry(registryPath)
 	if err != nil {
-		return utils.NewUserError(
-			"Failed to load installation registry",
-			err.Error(),
-			"Run 'binarius init' to initialize Binarius",
-		)
+		return err
+	}
+
+	effective, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkToolAllowed(effective, toolName); err != nil {
+		return err
+	}
+
+	// Load config.yaml before switching anything, so a file that can't be
+	// parsed is reported instead of being replaced with defaults
+	cfg, err := loadConfigForUpdate()
+	if err != nil {
+		return err
 	}
 
 	// Check if version is installed
@@ -95,12 +119,33 @@ func runUse(cmd *cobra.Command, args []string) error {
 			fmt.Sprintf("%s@%s is not installed", toolName, version),
 			"Version not found in registry",
 			fmt.Sprintf("Run 'binarius install %s@%s' to install it", toolName, version),
-		)
+		).WithCode(utils.CodeNotInstalled)
 	}
 
 	// Get tool version metadata
 	toolVersion := registry.GetVersion(toolName, version)
 
+	// Refuse to activate installations known to be unusable
+	if !toolVersion.IsComplete() {
+		return unusableVersionError(toolName, version, toolVersion.Status, "")
+	}
+
+	// Make sure the binary is still the one that was installed
+	if toolVersion.BinaryChecksum != "" {
+		err := installer.VerifyBinary(toolVersion.BinaryPath, toolVersion.BinaryChecksum)
+		if errors.Is(err, installer.ErrBinaryMissing) || errors.Is(err, installer.ErrBinaryModified) {
+			toolVersion.Status = config.StatusBroken
+			registry.AddVersion(toolName, version, toolVersion)
+			if saveErr := config.SaveRegistry(registry, registryPath); saveErr != nil {
+				noticef("⚠️  Warning: Failed to mark %s@%s as broken: %v\n", toolName, version, saveErr)
+			}
+			return unusableVersionError(toolName, version, config.StatusBroken, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create symlink
 	symlinkPath := filepath.Join(binDir, toolName)
 	sourcePath := toolVersion.BinaryPath
@@ -111,31 +156,35 @@ func runUse(cmd *cobra.Command, args []string) error {
 			fmt.Sprintf("Failed to create symlink at %s", symlinkPath),
 			err.Error(),
 			fmt.Sprintf("Ensure %s exists and is writable", binDir),
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
 
-	fmt.Printf("✓ Activated %s@%s\n", toolName, version)
-	fmt.Printf("Symlink: %s -> %s\n", symlinkPath, sourcePath)
+	messagef("✓ Activated %s@%s\n", toolName, version)
+	messagef("Symlink: %s -> %s\n", symlinkPath, sourcePath)
 
 	// Update config with default version
-	cfg, err := config.Load(configPath)
-	if err != nil {
-		// If config doesn't exist, create a default one
-		cfg, err = config.DefaultConfig()
-		if err != nil {
-			return err
-		}
-	}
-
 	cfg.SetDefault(toolName, version)
 	if err := config.Save(cfg, configPath); err != nil {
 		// Non-fatal: symlink is created, but config update failed
-		fmt.Printf("⚠️  Warning: Failed to update config.yaml with default version\n")
+		noticef("⚠️  Warning: Failed to update config.yaml with default version: %v\n", err)
 	} else {
-		fmt.Printf("Updated default version in config.yaml\n")
+		messagef("Updated default version in config.yaml\n")
 	}
 
-	fmt.Printf("\nYou can now use '%s' command with version %s\n", toolName, version)
+	messagef("\nYou can now use '%s' command with version %s\n", toolName, version)
 
 	return nil
 }
+
+// unusableVersionError explains why a partial or broken installation can't be activated.
+func unusableVersionError(toolName, version, status, reason string) error {
+	if reason == "" {
+		reason = fmt.Sprintf("The installation is marked as %s in the registry", status)
+	}
+
+	return utils.NewUserError(
+		fmt.Sprintf("%s@%s is %s and can't be activated", toolName, version, status),
+		reason,
+		fmt.Sprintf("Reinstall it with 'binarius install %s@%s'", toolName, version),
+	).WithCode(utils.CodeBroken)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/installer"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [tool[@version]]",
+	Short: "Check installed binaries for tampering",
+	Long: `Re-hash installed binaries and compare them to the checksums recorded at install time.
+
+Binaries that are missing or have been modified are marked as broken in the
+installation registry. Reinstall them to restore a trusted copy.
+
+Examples:
+  binarius verify                     # Verify every installed version
+  binarius verify terraform           # Verify all terraform versions
+  binarius verify terraform@v1.6.0    # Verify a single version`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeToolVersion(installedVersions),
+	RunE:              runVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	var toolFilter, versionFilter string
+	if len(args) == 1 {
+		toolFilter, versionFilter, _ = strings.Cut(args[0], "@")
+
+		if err := utils.ValidateToolName(toolFilter); err != nil {
+			return utils.NewUserError(
+				"Invalid tool name",
+				err.Error(),
+				"Tool name must be lowercase alphanumeric with hyphens only",
+			).WithCode(utils.CodeUsage)
+		}
+
+		if versionFilter != "" {
+			normalized, err := utils.NormalizeVersion(versionFilter)
+			if err != nil {
+				return utils.NewUserError(
+					"Invalid version format",
+					err.Error(),
+					"Version must follow semantic versioning (e.g., v1.6.0, 1.6.0-beta1)",
+				).WithCode(utils.CodeUsage)
+			}
+			versionFilter = normalized
+		}
+	}
+
+	registryPath, err := paths.RegistryFile()
+	if err != nil {
+		return err
+	}
+
+	// Hold the lock for the whole check so statuses are written against a current registry
+	homeLock, err := lockHome()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = homeLock.Release() }()
+
+	registry, err := loadRegistry(registryPath)
+	if err != nil {
+		return err
+	}
+
+	toolNames := registry.ListTools()
+	if toolFilter != "" {
+		if len(registry.ListVersions(toolFilter)) == 0 {
+			return utils.NewUserError(
+				fmt.Sprintf("%s is not installed", toolFilter),
+				"No versions found in registry",
+				"Run 'binarius list' to see installed tools",
+			).WithCode(utils.CodeNotInstalled)
+		}
+		toolNames = []string{toolFilter}
+	}
+	sort.Strings(toolNames)
+
+	var checked, broken, unverifiable int
+	changed := false
+	for _, toolName := range toolNames {
+		versions := registry.ListVersions(toolName)
+		if versionFilter != "" {
+			versions = []string{versionFilter}
+		}
+		sortVersions(versions)
+
+		for _, version := range versions {
+			if !registry.HasVersion(toolName, version) {
+				return utils.NewUserError(
+					fmt.Sprintf("%s@%s is not installed", toolName, version),
+					"Version not found in registry",
+					fmt.Sprintf("Run 'binarius list %s' to see installed versions", toolName),
+				).WithCode(utils.CodeNotInstalled)
+			}
+
+			tv := registry.GetVersion(toolName, version)
+			checked++
+
+			if tv.BinaryChecksum == "" {
+				noticef("⚠️  %s@%s: no binary checksum recorded (installed by an older Binarius)\n", toolName, version)
+				unverifiable++
+				continue
+			}
+
+			// A matching hash doesn't make a partial or broken install complete;
+			// only install and repair promote entries
+			err := installer.VerifyBinary(tv.BinaryPath, tv.BinaryChecksum)
+			switch {
+			case err == nil:
+				messagef("✓ %s@%s%s\n", toolName, version, statusSuffix(tv))
+			case errors.Is(err, installer.ErrBinaryMissing), errors.Is(err, installer.ErrBinaryModified):
+				messagef("✗ %s@%s: %v\n", toolName, version, err)
+				broken++
+				if tv.Status != config.StatusBroken {
+					tv.Status = config.StatusBroken
+					registry.AddVersion(toolName, version, tv)
+					changed = true
+				}
+			default:
+				return utils.NewUserError(
+					fmt.Sprintf("Failed to verify %s@%s", toolName, version),
+					err.Error(),
+					fmt.Sprintf("Ensure %s is readable", tv.BinaryPath),
+				).WithCode(utils.CodeFilesystem)
+			}
+		}
+	}
+
+	if changed {
+		if err := config.SaveRegistry(registry, registryPath); err != nil {
+			return utils.NewUserError(
+				"Failed to update installation registry",
+				err.Error(),
+				"Ensure ~/.binarius is writable",
+			).WithCode(utils.CodeFilesystem)
+		}
+	}
+
+	if checked == 0 {
+		messagef("No tools installed\n")
+		return nil
+	}
+
+	messagef("\nVerified %d installation(s): %d ok, %d broken, %d without checksum\n",
+		checked, checked-broken-unverifiable, broken, unverifiable)
+
+	if broken > 0 {
+		return utils.NewUserError(
+			fmt.Sprintf("%d installation(s) failed verification", broken),
+			"Binaries were modified or removed after installation",
+			"Reinstall them with 'binarius uninstall <tool>@<version> --force' followed by 'binarius install <tool>@<version>'",
+		).WithCode(utils.CodeBroken)
+	}
+
+	return nil
+}
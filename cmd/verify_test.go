@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/installer"
+)
+
+// TestVerifyLeavesPartialInstalls checks that a partial install whose binary
+// matches its checksum is reported but not promoted to complete.
+func TestVerifyLeavesPartialInstalls(t *testing.T) {
+	tmpDir := setupTestHome(t)
+
+	home := filepath.Join(tmpDir, ".binarius")
+	versionDir := filepath.Join(home, "tools", "terraform", "v1.6.0")
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(versionDir, "terraform")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	checksum, err := installer.ComputeSHA256(binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registryPath := filepath.Join(home, "installation.json")
+	registry := config.NewRegistry()
+	registry.AddVersion("terraform", "v1.6.0", config.ToolVersion{
+		BinaryPath:     binary,
+		BinaryChecksum: checksum,
+		Status:         config.StatusPartial,
+	})
+	if err := config.SaveRegistry(registry, registryPath); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, err := runCommand(t, "verify", "terraform")
+	if err != nil {
+		t.Fatalf("binarius verify failed: %v\nstderr: %s", err, stderr)
+	}
+	if want := "✓ terraform@v1.6.0 (partial)"; !strings.Contains(stdout, want) {
+		t.Errorf("verify output = %q, want it to contain %q", stdout, want)
+	}
+
+	registry, err = config.LoadRegistry(registryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := registry.GetVersion("terraform", "v1.6.0").Status; status != config.StatusPartial {
+		t.Errorf("status after verify = %q, want %q", status, config.StatusPartial)
+	}
+}
+
+// TestVerifyToolNotInstalled checks that verifying a tool with no installed
+// versions fails with E_NOT_INSTALLED.
+func TestVerifyToolNotInstalled(t *testing.T) {
+	setupTestHome(t)
+
+	_, _, err := runCommand(t, "verify", "terraform")
+	if code := utils.CodeOf(err); code != utils.CodeNotInstalled {
+		t.Errorf("binarius verify terraform error = %v (code %s), want %s", err, code, utils.CodeNotInstalled)
+	}
+}
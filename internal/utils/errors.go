@@ -1,10 +1,64 @@
 package utils
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Code identifies the kind of failure a UserError reports, so scripts can
+// react to it without parsing messages. Codes are stable once released.
+type Code string
+
+// Error codes, grouped by the exit status they map to.
+const (
+	CodeGeneric                 Code = "E_GENERIC"                  // Exit 1: anything not covered below
+	CodeUsage                   Code = "E_USAGE"                    // Exit 2: invalid arguments or flags
+	CodeConfig                  Code = "E_CONFIG"                   // Exit 3: config.yaml or installation.json can't be used
+	CodeNotInstalled            Code = "E_NOT_INSTALLED"            // Exit 4: tool or version isn't installed or active
+	CodeNotFound                Code = "E_NOT_FOUND"                // Exit 5: tool, version, or setting doesn't exist
+	CodeNetwork                 Code = "E_NETWORK"                  // Exit 6: download or connection failure
+	CodeRateLimited             Code = "E_RATE_LIMITED"             // Exit 7: the server asked to slow down
+	CodeChecksumMismatch        Code = "E_CHECKSUM_MISMATCH"        // Exit 8: file doesn't match its checksum
+	CodeSignatureInvalid        Code = "E_SIGNATURE_INVALID"        // Exit 8: checksums aren't validly signed
+	CodeVerificationUnavailable Code = "E_VERIFICATION_UNAVAILABLE" // Exit 8: required checksum or signature is missing
+	CodeBroken                  Code = "E_BROKEN"                   // Exit 8: installed binary is partial, missing, or modified
+	CodeLocked                  Code = "E_LOCKED"                   // Exit 9: another Binarius process holds the lock
+	CodeFilesystem              Code = "E_FILESYSTEM"               // Exit 10: file or directory can't be read or written
+	CodeNotAllowed              Code = "E_NOT_ALLOWED"              // Exit 11: forbidden by the system config
+	CodeUpdatesAvailable        Code = "E_UPDATES_AVAILABLE"        // Exit 12: 'outdated --fail-on' found updates
+)
+
+// exitCodes maps codes to process exit statuses.
+var exitCodes = map[Code]int{
+	CodeGeneric:                 1,
+	CodeUsage:                   2,
+	CodeConfig:                  3,
+	CodeNotInstalled:            4,
+	CodeNotFound:                5,
+	CodeNetwork:                 6,
+	CodeRateLimited:             7,
+	CodeChecksumMismatch:        8,
+	CodeSignatureInvalid:        8,
+	CodeVerificationUnavailable: 8,
+	CodeBroken:                  8,
+	CodeLocked:                  9,
+	CodeFilesystem:              10,
+	CodeNotAllowed:              11,
+	CodeUpdatesAvailable:        12,
+}
+
+// ExitCode returns the process exit status for a code.
+func (c Code) ExitCode() int {
+	if code, ok := exitCodes[c]; ok {
+		return code
+	}
+	return 1
+}
 
 // UserError represents a user-facing error with context, reason, and actionable guidance.
 // It implements the error interface and formats messages for clear communication.
 type UserError struct {
+	Code    Code   // Kind of failure; empty means CodeGeneric
 	Context string // What operation failed
 	Reason  string // Why it failed (in user-friendly terms)
 	Action  string // What the user should do to fix it
@@ -24,3 +78,23 @@ func NewUserError(context, reason, action string) *UserError {
 		Action:  action,
 	}
 }
+
+// WithCode sets the error's code and returns the error, for chaining onto NewUserError.
+func (e *UserError) WithCode(code Code) *UserError {
+	e.Code = code
+	return e
+}
+
+// CodeOf returns the code of the first UserError in err's chain, or
+// CodeGeneric if there is none. Returns "" for a nil error.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+
+	var userErr *UserError
+	if errors.As(err, &userErr) && userErr.Code != "" {
+		return userErr.Code
+	}
+	return CodeGeneric
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -137,3 +139,49 @@ func TestUserError_Formatting(t *testing.T) {
 		t.Errorf("Third line should start with 'Action: ', got %q", lines[2])
 	}
 }
+
+func TestCodeOf(t *testing.T) {
+	notInstalled := NewUserError("terraform@v1.6.0 is not installed", "", "").WithCode(CodeNotInstalled)
+
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "plain error", err: errors.New("boom"), want: CodeGeneric},
+		{name: "user error without code", err: NewUserError("a", "b", "c"), want: CodeGeneric},
+		{name: "user error with code", err: notInstalled, want: CodeNotInstalled},
+		{name: "wrapped user error", err: fmt.Errorf("wrapped: %w", notInstalled), want: CodeNotInstalled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode_ExitCode(t *testing.T) {
+	tests := []struct {
+		code Code
+		want int
+	}{
+		{CodeGeneric, 1},
+		{CodeUsage, 2},
+		{CodeNotInstalled, 4},
+		{CodeRateLimited, 7},
+		{CodeChecksumMismatch, 8},
+		{CodeSignatureInvalid, 8},
+		{Code("E_UNKNOWN"), 1},
+		{Code(""), 1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.code.ExitCode(); got != tt.want {
+			t.Errorf("%q.ExitCode() = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+)
+
+// NewLogger returns a logger that writes records at level or above to console
+// as text, and every record, including debug records, to logFile as JSON.
+// logFile may be nil to log to the console only.
+//
+// Packages log through slog's default logger; cmd installs the logger
+// returned here with slog.SetDefault.
+func NewLogger(console io.Writer, level slog.Level, logFile io.Writer) *slog.Logger {
+	handlers := []slog.Handler{
+		slog.NewTextHandler(console, &slog.HandlerOptions{
+			Level: level,
+			// Timestamps add noise to a terminal; the log file keeps them
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}),
+	}
+	if logFile != nil {
+		handlers = append(handlers, slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	return slog.New(teeHandler(handlers))
+}
+
+// teeHandler sends each record to every handler that is enabled for its level.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
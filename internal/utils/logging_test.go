@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name        string
+		level       slog.Level
+		wantConsole []string // Messages expected on the console
+	}{
+		{"warnings only", slog.LevelWarn, []string{"warn"}},
+		{"verbose", slog.LevelInfo, []string{"info", "warn"}},
+		{"debug", slog.LevelDebug, []string{"debug", "info", "warn"}},
+		{"quiet", slog.LevelError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var console, logFile bytes.Buffer
+			logger := NewLogger(&console, tt.level, &logFile).With("tool", "terraform")
+
+			logger.Debug("debug")
+			logger.Info("info")
+			logger.Warn("warn")
+
+			for _, msg := range []string{"debug", "info", "warn"} {
+				want := false
+				for _, m := range tt.wantConsole {
+					want = want || m == msg
+				}
+				if got := strings.Contains(console.String(), "msg="+msg); got != want {
+					t.Errorf("console contains %q = %v, want %v\n%s", msg, got, want, console.String())
+				}
+			}
+			if strings.Contains(console.String(), "time=") {
+				t.Errorf("console output contains a timestamp: %s", console.String())
+			}
+
+			// The log file gets every record as JSON, whatever the console level
+			lines := strings.Split(strings.TrimSpace(logFile.String()), "\n")
+			if len(lines) != 3 {
+				t.Fatalf("log file has %d records, want 3:\n%s", len(lines), logFile.String())
+			}
+			for _, line := range lines {
+				var record map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("log file record %q is not JSON: %v", line, err)
+				}
+				if record["tool"] != "terraform" || record["time"] == nil {
+					t.Errorf("log file record = %v, want tool and time attributes", record)
+				}
+			}
+		})
+	}
+}
+
+func TestNewLogger_NoLogFile(t *testing.T) {
+	var console bytes.Buffer
+	logger := NewLogger(&console, slog.LevelInfo, nil)
+
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Enabled(Debug) = true without a log file at info level")
+	}
+	logger.Info("downloading", "url", "https://example.com")
+	if !strings.Contains(console.String(), `msg=downloading url=https://example.com`) {
+		t.Errorf("console = %q", console.String())
+	}
+}
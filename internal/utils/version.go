@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverRegex captures the parts of a version accepted by ValidateVersion.
+var semverRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([a-zA-Z0-9.-]+))?$`)
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // e.g. "beta1" or "rc.1"; empty for releases
+}
+
+// ParseVersion parses a version such as v1.6.0 or 1.6.0-rc.1.
+func ParseVersion(version string) (Version, error) {
+	m := semverRegex.FindStringSubmatch(version)
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid version %q: must follow semantic versioning (e.g., v1.6.0, 1.6.0-beta1)", version)
+	}
+
+	var v Version
+	var err error
+	if v.Major, err = strconv.Atoi(m[1]); err != nil {
+		return Version{}, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	if v.Minor, err = strconv.Atoi(m[2]); err != nil {
+		return Version{}, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	if v.Patch, err = strconv.Atoi(m[3]); err != nil {
+		return Version{}, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	v.Prerelease = m[4]
+
+	return v, nil
+}
+
+// IsPrerelease reports whether a version has a pre-release suffix.
+// Versions that can't be parsed are treated as pre-releases.
+func IsPrerelease(version string) bool {
+	v, err := ParseVersion(version)
+	return err != nil || v.Prerelease != ""
+}
+
+// CompareVersions compares two versions by semantic versioning precedence and
+// returns -1, 0, or 1. A pre-release sorts before its release, and versions
+// that can't be parsed sort before all others.
+func CompareVersions(a, b string) int {
+	va, errA := ParseVersion(a)
+	vb, errB := ParseVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+
+	for _, d := range []int{va.Major - vb.Major, va.Minor - vb.Minor, va.Patch - vb.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	return comparePrerelease(va.Prerelease, vb.Prerelease)
+}
+
+// comparePrerelease compares pre-release suffixes: numeric identifiers
+// numerically, others lexically, and a release ("") after any pre-release.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				return sign(numA - numB)
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return sign(len(partsA) - len(partsB))
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Update is the size of the change between two versions.
+type Update string
+
+// Update sizes, from smallest to largest.
+const (
+	UpdateNone  Update = ""
+	UpdatePatch Update = "patch"
+	UpdateMinor Update = "minor"
+	UpdateMajor Update = "major"
+)
+
+// ParseUpdate validates an update size name such as "minor".
+func ParseUpdate(name string) (Update, error) {
+	switch u := Update(name); u {
+	case UpdatePatch, UpdateMinor, UpdateMajor:
+		return u, nil
+	default:
+		return UpdateNone, fmt.Errorf("invalid update type %q: must be patch, minor, or major", name)
+	}
+}
+
+// AtLeast reports whether u is as large as or larger than other.
+func (u Update) AtLeast(other Update) bool {
+	return u.rank() >= other.rank()
+}
+
+func (u Update) rank() int {
+	switch u {
+	case UpdatePatch:
+		return 1
+	case UpdateMinor:
+		return 2
+	case UpdateMajor:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// UpdateBetween returns the size of the update from current to latest, or
+// UpdateNone if latest isn't newer. A newer pre-release or build of the same
+// major.minor.patch counts as a patch update.
+func UpdateBetween(current, latest string) Update {
+	if CompareVersions(latest, current) <= 0 {
+		return UpdateNone
+	}
+
+	from, errFrom := ParseVersion(current)
+	to, errTo := ParseVersion(latest)
+	switch {
+	case errFrom != nil || errTo != nil || to.Major != from.Major:
+		return UpdateMajor
+	case to.Minor != from.Minor:
+		return UpdateMinor
+	default:
+		return UpdatePatch
+	}
+}
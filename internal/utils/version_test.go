@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Version
+		wantErr bool
+	}{
+		{"v1.6.0", Version{1, 6, 0, ""}, false},
+		{"1.10.2", Version{1, 10, 2, ""}, false},
+		{"v1.7.0-rc.1", Version{1, 7, 0, "rc.1"}, false},
+		{"v1.6", Version{}, true},
+		{"latest", Version{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParseVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseVersion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	// Sorted by precedence, oldest first
+	want := []string{
+		"garbage",
+		"v1.2.3",
+		"v1.9.0",
+		"v1.10.0-alpha",
+		"v1.10.0-alpha.1",
+		"v1.10.0-alpha.beta",
+		"v1.10.0-beta.2",
+		"v1.10.0-beta.11",
+		"v1.10.0-rc.1",
+		"v1.10.0",
+		"v2.0.0",
+	}
+
+	got := []string{"v1.10.0", "v2.0.0", "v1.10.0-beta.11", "garbage", "v1.9.0", "v1.10.0-alpha.beta",
+		"v1.10.0-rc.1", "v1.2.3", "v1.10.0-alpha.1", "v1.10.0-beta.2", "v1.10.0-alpha"}
+	sort.Slice(got, func(i, j int) bool { return CompareVersions(got[i], got[j]) < 0 })
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted versions = %v, want %v", got, want)
+	}
+
+	if c := CompareVersions("1.6.0", "v1.6.0"); c != 0 {
+		t.Errorf("CompareVersions(1.6.0, v1.6.0) = %d, want 0", c)
+	}
+}
+
+func TestUpdateBetween(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            Update
+	}{
+		{"v1.6.0", "v1.6.0", UpdateNone},
+		{"v1.6.1", "v1.6.0", UpdateNone},
+		{"v1.6.0", "v1.6.1", UpdatePatch},
+		{"v1.7.0-rc.1", "v1.7.0", UpdatePatch},
+		{"v1.6.1", "v1.7.0", UpdateMinor},
+		{"v1.9.5", "v2.0.0", UpdateMajor},
+		{"v0.54.0", "v0.55.1", UpdateMinor},
+	}
+
+	for _, tt := range tests {
+		if got := UpdateBetween(tt.current, tt.latest); got != tt.want {
+			t.Errorf("UpdateBetween(%q, %q) = %q, want %q", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestUpdate_AtLeast(t *testing.T) {
+	tests := []struct {
+		u, other Update
+		want     bool
+	}{
+		{UpdateMajor, UpdateMinor, true},
+		{UpdateMinor, UpdateMinor, true},
+		{UpdatePatch, UpdateMinor, false},
+		{UpdateNone, UpdatePatch, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.u.AtLeast(tt.other); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.u, tt.other, got, tt.want)
+		}
+	}
+
+	if _, err := ParseUpdate("huge"); err == nil {
+		t.Error("ParseUpdate(huge) should fail")
+	}
+}
@@ -23,6 +23,6 @@ func main() {
 
 	// Execute the root command
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
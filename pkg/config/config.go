@@ -1,7 +1,9 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -9,16 +11,69 @@ import (
 )
 
 // PathConfig holds directory path configuration for Binarius.
+// Empty fields fall back to the environment variables and defaults used by pkg/paths.
 type PathConfig struct {
-	BinariusHome string `yaml:"binarius_home"` // Binarius home directory (stores tools, cache, config, registry)
-	BinDir       string `yaml:"bin_dir"`       // Symlink directory (active tool versions)
-	CacheDir     string `yaml:"cache_dir"`     // Downloaded archives cache directory
+	BinariusHome string `yaml:"binarius_home,omitempty"` // Binarius home directory (stores tools, cache, registry)
+	BinDir       string `yaml:"bin_dir,omitempty"`       // Symlink directory (active tool versions)
+	CacheDir     string `yaml:"cache_dir,omitempty"`     // Downloaded archives cache directory
+}
+
+// MirrorConfig holds mirror base URLs that replace upstream release hosts.
+type MirrorConfig struct {
+	Default string            `yaml:"default,omitempty"` // Mirror base URL applied to every tool
+	Tools   map[string]string `yaml:"tools,omitempty"`   // Per-tool mirror base URLs (take precedence over Default)
+	Hosts   map[string]string `yaml:"hosts,omitempty"`   // Per-upstream-host mirror base URLs (take precedence over Tools and Default)
+}
+
+// VerificationConfig holds the verification policy ("strict", "checksum", or "none") for installs.
+type VerificationConfig struct {
+	Default string            `yaml:"default,omitempty"` // Policy applied to every tool
+	Tools   map[string]string `yaml:"tools,omitempty"`   // Per-tool policies (take precedence over Default)
+}
+
+// TLSConfig holds TLS settings for outgoing HTTPS connections.
+type TLSConfig struct {
+	CABundle   string `yaml:"ca_bundle,omitempty"`   // PEM file with additional trusted CAs (e.g. a TLS-intercepting proxy)
+	ClientCert string `yaml:"client_cert,omitempty"` // PEM client certificate for mirrors that require mTLS
+	ClientKey  string `yaml:"client_key,omitempty"`  // PEM private key for ClientCert
+}
+
+// GPGConfig holds settings for verifying signed checksum files.
+type GPGConfig struct {
+	KeyFiles []string `yaml:"key_files,omitempty"` // Additional ASCII-armored public keys to trust (e.g. after a vendor key rotation)
+}
+
+// ToolsConfig restricts which tools can be installed and activated.
+type ToolsConfig struct {
+	Allowed []string `yaml:"allowed,omitempty"` // Tools that may be used; empty allows every tool
+}
+
+// PruneConfig holds settings for 'binarius prune'.
+type PruneConfig struct {
+	ProjectDirs []string `yaml:"project_dirs,omitempty"` // Directories searched for version files whose versions are never pruned
 }
 
 // Config represents the Binarius configuration stored in config.yaml.
 type Config struct {
-	Defaults map[string]string `yaml:"defaults"` // Map of tool names to default active versions
-	Paths    PathConfig        `yaml:"paths"`    // Directory paths configuration
+	SchemaVersion int                `yaml:"schema_version"`         // Layout version, see ConfigSchemaVersion
+	Defaults      map[string]string  `yaml:"defaults"`               // Map of tool names to default active versions
+	Paths         PathConfig         `yaml:"paths,omitempty"`        // Directory paths configuration
+	Mirrors       MirrorConfig       `yaml:"mirrors,omitempty"`      // Download mirror configuration
+	TLS           TLSConfig          `yaml:"tls,omitempty"`          // TLS settings for outgoing connections
+	GPG           GPGConfig          `yaml:"gpg,omitempty"`          // Signature verification settings
+	Verification  VerificationConfig `yaml:"verification,omitempty"` // Verification policies
+	Tools         ToolsConfig        `yaml:"tools,omitempty"`        // Tool restrictions
+	Prune         PruneConfig        `yaml:"prune,omitempty"`        // Settings for pruning old versions
+	Locked        []string           `yaml:"locked,omitempty"`       // Keys users can't override; only read from the system config
+}
+
+// New returns an empty Config with the current schema version.
+// Unset paths fall back to the defaults of pkg/paths.
+func New() *Config {
+	return &Config{
+		SchemaVersion: ConfigSchemaVersion,
+		Defaults:      make(map[string]string),
+	}
 }
 
 // DefaultConfig returns a Config with default values based on the user's home directory.
@@ -29,7 +84,8 @@ func DefaultConfig() (*Config, error) {
 	}
 
 	return &Config{
-		Defaults: make(map[string]string),
+		SchemaVersion: ConfigSchemaVersion,
+		Defaults:      make(map[string]string),
 		Paths: PathConfig{
 			BinariusHome: filepath.Join(homeDir, ".binarius"),
 			BinDir:       filepath.Join(homeDir, ".local", "bin"),
@@ -40,15 +96,114 @@ func DefaultConfig() (*Config, error) {
 
 // Load reads and parses the configuration file from the specified path.
 // Returns an error if the file doesn't exist or contains invalid YAML.
+//
+// Configs written with an older schema are migrated to ConfigSchemaVersion in
+// memory; the file itself is only upgraded by MigrateConfigFile. A config
+// written by a newer Binarius returns a *SchemaError.
 func Load(path string) (*Config, error) {
+	return load(path, false)
+}
+
+// LoadStrict is like Load, but keys that Config doesn't know are an error.
+// Use it before saving a loaded config: Save would drop those keys.
+func LoadStrict(path string) (*Config, error) {
+	return load(path, true)
+}
+
+func load(path string, strict bool) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
 
+	config, _, err := parse(path, data, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// MigrateConfigFile upgrades the config file at path to ConfigSchemaVersion
+// in place, keeping the original as "<path>.bak-v<old version>". Keys Config
+// doesn't know are kept. Missing and current files, and files that can't be
+// parsed, are left alone; Load reports the latter. Reports whether the file
+// was migrated.
+//
+// Callers must hold the lock on the Binarius home directory.
+func MigrateConfigFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	doc, from, err := decodeConfig(path, data)
+	if IsNewerSchema(err) {
+		return false, err
+	}
+	if err != nil || from == ConfigSchemaVersion {
+		// Leave files that can't be parsed for repair or editing
+		return false, nil
+	}
+
+	migrated, err := yaml.Marshal(doc)
+	if err != nil {
+		return false, fmt.Errorf("failed to migrate config file %s: %w", path, err)
+	}
+	if _, err := backupFile(path, from, data); err != nil {
+		return false, err
+	}
+	if err := SaveData(migrated, path); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ValidateFile checks a configuration file without changing it. See ValidateData.
+func ValidateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return ValidateData(path, data)
+}
+
+// ValidateData checks the contents of a configuration file: unknown keys are
+// reported (catching typos that Load silently ignores), and every setting is
+// checked with Validate. path is only used in error messages.
+func ValidateData(path string, data []byte) error {
+	config, _, err := parse(path, data, true)
+	if err != nil {
+		return err
+	}
+	return config.Validate()
+}
+
+// parse decodes config.yaml contents, migrating them in memory to the current
+// schema. In strict mode, keys that Config doesn't know are an error.
+// Returns the schema version the data had before migrating.
+func parse(path string, data []byte, strict bool) (*Config, int, error) {
+	doc, from, err := decodeConfig(path, data)
+	if err != nil {
+		return nil, from, err
+	}
+
+	migrated := data
+	if from < ConfigSchemaVersion {
+		if migrated, err = yaml.Marshal(doc); err != nil {
+			return nil, from, fmt.Errorf("failed to migrate config file %s: %w", path, err)
+		}
+	}
+
 	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	decoder := yaml.NewDecoder(bytes.NewReader(migrated))
+	decoder.KnownFields(strict)
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		return nil, from, fmt.Errorf("failed to parse config file %s: %w", path, err)
 	}
 
 	// Initialize Defaults map if it's nil
@@ -56,37 +211,55 @@ func Load(path string) (*Config, error) {
 		config.Defaults = make(map[string]string)
 	}
 
-	return &config, nil
+	return &config, from, nil
+}
+
+// decodeConfig decodes config.yaml contents generically, so migrations can
+// handle layouts Config no longer describes, and migrates them to the current
+// schema. Returns the schema version the data had before migrating.
+func decodeConfig(path string, data []byte) (map[string]interface{}, int, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+
+	from, err := migrate(path, doc, ConfigSchemaVersion, configMigrations)
+	if err != nil {
+		return nil, from, err
+	}
+	return doc, from, nil
 }
 
 // Save writes the configuration to the specified path using atomic write pattern.
-// The configuration is written to a temporary file and then renamed to ensure atomicity.
+// The configuration is written to a uniquely named temporary file and then renamed to ensure atomicity.
+// The configuration is always written with the current schema version.
 func Save(config *Config, path string) error {
+	config.SchemaVersion = ConfigSchemaVersion
+
 	// Marshal config to YAML
 	data, err := yaml.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	// Ensure parent directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory %s: %w", dir, err)
+	// Write via uniquely named temporary file and atomic rename
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
 	}
 
-	// Write to temporary file
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary config file: %w", err)
-	}
+	return nil
+}
 
-	// Atomic rename
-	if err := os.Rename(tmpPath, path); err != nil {
-		// Clean up temporary file on failure
-		_ = os.Remove(tmpPath)
+// SaveData writes raw configuration file contents, such as a file edited by
+// hand, using the same atomic write pattern as Save. Unlike Save, comments and
+// formatting are kept. Check the contents with ValidateData first.
+func SaveData(data []byte, path string) error {
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to save config file: %w", err)
 	}
-
 	return nil
 }
 
@@ -112,3 +285,43 @@ func (c *Config) GetDefault(tool string) string {
 	}
 	return c.Defaults[tool]
 }
+
+// GetMirror returns the mirror base URL for a tool.
+// A per-tool mirror takes precedence over the global default.
+// Returns an empty string if no mirror is configured.
+func (c *Config) GetMirror(tool string) string {
+	if mirror := c.Mirrors.Tools[tool]; mirror != "" {
+		return mirror
+	}
+	return c.Mirrors.Default
+}
+
+// GetHostMirrors returns the mirror base URLs keyed by the upstream host they
+// replace, e.g. "github.com". They take precedence over GetMirror.
+func (c *Config) GetHostMirrors() map[string]string {
+	return c.Mirrors.Hosts
+}
+
+// GetVerification returns the verification policy for a tool.
+// A per-tool policy takes precedence over the default policy.
+// Returns an empty string if no policy is configured.
+func (c *Config) GetVerification(tool string) string {
+	if policy := c.Verification.Tools[tool]; policy != "" {
+		return policy
+	}
+	return c.Verification.Default
+}
+
+// IsToolAllowed reports whether tools.allowed permits a tool.
+// Every tool is allowed when the list is empty.
+func (c *Config) IsToolAllowed(tool string) bool {
+	if len(c.Tools.Allowed) == 0 {
+		return true
+	}
+	for _, allowed := range c.Tools.Allowed {
+		if allowed == tool {
+			return true
+		}
+	}
+	return false
+}
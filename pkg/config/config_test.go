@@ -266,6 +266,9 @@ func TestSave_AtomicWrite(t *testing.T) {
 	if _, err := os.Stat(tmpPath); !os.IsNotExist(err) {
 		t.Error("Save() atomic write left temporary file")
 	}
+	if matches, _ := filepath.Glob(configPath + ".tmp*"); len(matches) > 0 {
+		t.Errorf("Save() atomic write left temporary files: %v", matches)
+	}
 
 	// Verify final content
 	loaded, err := Load(configPath)
@@ -418,3 +421,108 @@ func TestSave_CreateDirectory(t *testing.T) {
 		t.Errorf("Save() did not create nested directories: %v", err)
 	}
 }
+
+func TestGetMirror(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		tool   string
+		want   string
+	}{
+		{
+			name:   "no mirrors configured",
+			config: &Config{},
+			tool:   "terraform",
+			want:   "",
+		},
+		{
+			name: "global mirror",
+			config: &Config{
+				Mirrors: MirrorConfig{Default: "https://mirror.example.com"},
+			},
+			tool: "terraform",
+			want: "https://mirror.example.com",
+		},
+		{
+			name: "per-tool mirror overrides global",
+			config: &Config{
+				Mirrors: MirrorConfig{
+					Default: "https://mirror.example.com",
+					Tools:   map[string]string{"tofu": "https://github-mirror.example.com"},
+				},
+			},
+			tool: "tofu",
+			want: "https://github-mirror.example.com",
+		},
+		{
+			name: "other tools fall back to global",
+			config: &Config{
+				Mirrors: MirrorConfig{
+					Default: "https://mirror.example.com",
+					Tools:   map[string]string{"tofu": "https://github-mirror.example.com"},
+				},
+			},
+			tool: "terraform",
+			want: "https://mirror.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.config.GetMirror(tt.tool)
+			if got != tt.want {
+				t.Errorf("GetMirror() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetHostMirrors verifies per-host mirrors are read from config.yaml.
+func TestGetHostMirrors(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `mirrors:
+  default: https://artifactory.example.com/hashicorp
+  hosts:
+    github.com: https://artifactory.example.com/github
+    api.github.com: https://artifactory.example.com/github-api
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	hosts := cfg.GetHostMirrors()
+	if hosts["github.com"] != "https://artifactory.example.com/github" || hosts["api.github.com"] != "https://artifactory.example.com/github-api" {
+		t.Errorf("GetHostMirrors() = %v", hosts)
+	}
+	if got := cfg.GetMirror("terraform"); got != "https://artifactory.example.com/hashicorp" {
+		t.Errorf("GetMirror() = %q, want the default mirror", got)
+	}
+}
+
+// TestGetVerification verifies per-tool verification policy resolution.
+func TestGetVerification(t *testing.T) {
+	config := &Config{
+		Verification: VerificationConfig{
+			Default: "strict",
+			Tools:   map[string]string{"terragrunt": "checksum"},
+		},
+	}
+
+	if got := config.GetVerification("terragrunt"); got != "checksum" {
+		t.Errorf("GetVerification(terragrunt) = %q, want %q", got, "checksum")
+	}
+	if got := config.GetVerification("terraform"); got != "strict" {
+		t.Errorf("GetVerification(terraform) = %q, want %q", got, "strict")
+	}
+	if got := (&Config{}).GetVerification("terraform"); got != "" {
+		t.Errorf("GetVerification() without config = %q, want empty", got)
+	}
+}
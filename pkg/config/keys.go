@@ -0,0 +1,387 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// Value types of settings.
+const (
+	TypeVersion = "version" // Semantic version, stored with a "v" prefix
+	TypePath    = "path"    // Absolute path or a path starting with ~/
+	TypePaths   = "paths"   // Comma-separated list of paths
+	TypeURL     = "url"     // http(s) URL
+	TypePolicy  = "policy"  // Verification policy: strict, checksum, or none
+	TypeTool    = "tool"    // Tool name
+	TypeTools   = "tools"   // Comma-separated list of tool names
+)
+
+// Setting describes a config.yaml key that can be read and written by name,
+// e.g. with 'binarius config set'. Keys ending in "<tool>" or "<host>" stand
+// for one entry per tool or upstream host, such as "defaults.terraform".
+type Setting struct {
+	Key         string
+	Type        string
+	Description string
+
+	field func(c *Config) *string            // Scalar settings
+	table func(c *Config) *map[string]string // Per-tool and per-host settings
+	list  func(c *Config) *[]string          // List settings
+}
+
+// KeyValue is a setting that has a value.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// Placeholders for the entry name of per-tool and per-host keys.
+const (
+	toolPlaceholder = "<tool>"
+	hostPlaceholder = "<host>"
+)
+
+// hostPattern matches a lowercase host name with an optional port.
+var hostPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?(:[0-9]+)?$`)
+
+// settings lists every setting in the order 'binarius config list' shows them.
+var settings = []Setting{
+	{
+		Key: "defaults.<tool>", Type: TypeVersion, Description: "Active version of a tool, set by 'binarius use'",
+		table: func(c *Config) *map[string]string { return &c.Defaults },
+	},
+	{
+		Key: "paths.binarius_home", Type: TypePath, Description: "Directory for the registry, tools, and cache",
+		field: func(c *Config) *string { return &c.Paths.BinariusHome },
+	},
+	{
+		Key: "paths.bin_dir", Type: TypePath, Description: "Directory for tool symlinks",
+		field: func(c *Config) *string { return &c.Paths.BinDir },
+	},
+	{
+		Key: "paths.cache_dir", Type: TypePath, Description: "Directory for downloaded archives",
+		field: func(c *Config) *string { return &c.Paths.CacheDir },
+	},
+	{
+		Key: "mirrors.default", Type: TypeURL, Description: "Mirror base URL for every tool",
+		field: func(c *Config) *string { return &c.Mirrors.Default },
+	},
+	{
+		Key: "mirrors.tools.<tool>", Type: TypeURL, Description: "Mirror base URL for one tool",
+		table: func(c *Config) *map[string]string { return &c.Mirrors.Tools },
+	},
+	{
+		Key: "mirrors.hosts.<host>", Type: TypeURL, Description: "Mirror base URL for one upstream host, e.g. github.com",
+		table: func(c *Config) *map[string]string { return &c.Mirrors.Hosts },
+	},
+	{
+		Key: "tls.ca_bundle", Type: TypePath, Description: "PEM file with additional trusted CAs",
+		field: func(c *Config) *string { return &c.TLS.CABundle },
+	},
+	{
+		Key: "tls.client_cert", Type: TypePath, Description: "PEM client certificate for mTLS",
+		field: func(c *Config) *string { return &c.TLS.ClientCert },
+	},
+	{
+		Key: "tls.client_key", Type: TypePath, Description: "PEM private key for tls.client_cert",
+		field: func(c *Config) *string { return &c.TLS.ClientKey },
+	},
+	{
+		Key: "gpg.key_files", Type: TypePaths, Description: "Additional ASCII-armored public keys to trust",
+		list: func(c *Config) *[]string { return &c.GPG.KeyFiles },
+	},
+	{
+		Key: "verification.default", Type: TypePolicy, Description: "Verification policy for every tool",
+		field: func(c *Config) *string { return &c.Verification.Default },
+	},
+	{
+		Key: "verification.tools.<tool>", Type: TypePolicy, Description: "Verification policy for one tool",
+		table: func(c *Config) *map[string]string { return &c.Verification.Tools },
+	},
+	{
+		Key: "tools.allowed", Type: TypeTools, Description: "Tools that may be installed and activated (default: all)",
+		list: func(c *Config) *[]string { return &c.Tools.Allowed },
+	},
+	{
+		Key: "prune.project_dirs", Type: TypePaths, Description: "Directories whose version files protect versions from 'binarius prune'",
+		list: func(c *Config) *[]string { return &c.Prune.ProjectDirs },
+	},
+}
+
+// Settings returns every setting that can be addressed by key.
+func Settings() []Setting {
+	return append([]Setting(nil), settings...)
+}
+
+// LookupSetting finds the setting for a key. For per-tool and per-host keys,
+// the tool or host name is returned as well.
+func LookupSetting(key string) (Setting, string, error) {
+	for _, setting := range settings {
+		placeholder := setting.placeholder()
+		if placeholder == "" {
+			if setting.Key == key {
+				return setting, "", nil
+			}
+			continue
+		}
+
+		prefix := strings.TrimSuffix(setting.Key, placeholder)
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok || name == "" || (placeholder == toolPlaceholder && strings.Contains(name, ".")) {
+			continue
+		}
+		if err := setting.validateName(name); err != nil {
+			return Setting{}, "", fmt.Errorf("invalid key %q: %w", key, err)
+		}
+		return setting, name, nil
+	}
+
+	return Setting{}, "", fmt.Errorf("unknown key %q", key)
+}
+
+// placeholder returns the placeholder a per-tool or per-host key ends in, or
+// "" for other keys.
+func (s Setting) placeholder() string {
+	for _, placeholder := range []string{toolPlaceholder, hostPlaceholder} {
+		if strings.HasSuffix(s.Key, placeholder) {
+			return placeholder
+		}
+	}
+	return ""
+}
+
+// entryKey returns the key of one entry of a per-tool or per-host setting,
+// e.g. "defaults.terraform".
+func (s Setting) entryKey(name string) string {
+	return strings.TrimSuffix(s.Key, s.placeholder()) + name
+}
+
+// validateName checks the tool or host name of an entry of a per-tool or
+// per-host setting.
+func (s Setting) validateName(name string) error {
+	if s.placeholder() == hostPlaceholder {
+		if !hostPattern.MatchString(name) {
+			return fmt.Errorf("%q is not a valid host name (expected e.g. github.com)", name)
+		}
+		return nil
+	}
+	return utils.ValidateToolName(name)
+}
+
+// Get returns the value of a key and whether it is set.
+// List values are joined with commas.
+func (c *Config) Get(key string) (string, bool, error) {
+	setting, tool, err := LookupSetting(key)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch {
+	case setting.table != nil:
+		value, ok := (*setting.table(c))[tool]
+		return value, ok && value != "", nil
+	case setting.list != nil:
+		values := *setting.list(c)
+		return strings.Join(values, ","), len(values) > 0, nil
+	default:
+		value := *setting.field(c)
+		return value, value != "", nil
+	}
+}
+
+// Set validates and sets the value of a key. List values are comma-separated.
+func (c *Config) Set(key, value string) error {
+	setting, tool, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+
+	if setting.list != nil {
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if _, err := validateValue(itemType(setting.Type), item); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+			values = append(values, item)
+		}
+		*setting.list(c) = values
+		return nil
+	}
+
+	normalized, err := validateValue(setting.Type, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if setting.table != nil {
+		table := setting.table(c)
+		if *table == nil {
+			*table = make(map[string]string)
+		}
+		(*table)[tool] = normalized
+		return nil
+	}
+
+	*setting.field(c) = normalized
+	return nil
+}
+
+// Unset removes a key. Reports whether it was set.
+func (c *Config) Unset(key string) (bool, error) {
+	_, wasSet, err := c.Get(key)
+	if err != nil {
+		return false, err
+	}
+
+	setting, tool, _ := LookupSetting(key)
+	switch {
+	case setting.table != nil:
+		delete(*setting.table(c), tool)
+	case setting.list != nil:
+		*setting.list(c) = nil
+	default:
+		*setting.field(c) = ""
+	}
+
+	return wasSet, nil
+}
+
+// List returns every key that has a value, in the order of Settings with
+// per-tool and per-host keys sorted by name.
+func (c *Config) List() []KeyValue {
+	var values []KeyValue
+	for _, setting := range settings {
+		if setting.table != nil {
+			table := *setting.table(c)
+			tools := make([]string, 0, len(table))
+			for tool := range table {
+				tools = append(tools, tool)
+			}
+			sort.Strings(tools)
+
+			for _, tool := range tools {
+				if table[tool] != "" {
+					values = append(values, KeyValue{setting.entryKey(tool), table[tool]})
+				}
+			}
+			continue
+		}
+
+		if value, ok, _ := c.Get(setting.Key); ok {
+			values = append(values, KeyValue{setting.Key, value})
+		}
+	}
+	return values
+}
+
+// Validate checks every setting against its type and the relationships
+// between settings. All problems are reported, joined into one error.
+func (c *Config) Validate() error {
+	var problems []error
+
+	for _, setting := range settings {
+		switch {
+		case setting.table != nil:
+			for tool, value := range *setting.table(c) {
+				key := setting.entryKey(tool)
+				if err := setting.validateName(tool); err != nil {
+					problems = append(problems, fmt.Errorf("%s: %w", key, err))
+					continue
+				}
+				if _, err := validateValue(setting.Type, value); err != nil {
+					problems = append(problems, fmt.Errorf("%s: %w", key, err))
+				}
+			}
+		case setting.list != nil:
+			for _, value := range *setting.list(c) {
+				if _, err := validateValue(itemType(setting.Type), value); err != nil {
+					problems = append(problems, fmt.Errorf("%s: %w", setting.Key, err))
+				}
+			}
+		default:
+			if value := *setting.field(c); value != "" {
+				if _, err := validateValue(setting.Type, value); err != nil {
+					problems = append(problems, fmt.Errorf("%s: %w", setting.Key, err))
+				}
+			}
+		}
+	}
+
+	for _, key := range c.Locked {
+		if !IsLockable(key) {
+			problems = append(problems, fmt.Errorf("locked: unknown key %q", key))
+		}
+	}
+
+	if (c.TLS.ClientCert == "") != (c.TLS.ClientKey == "") {
+		problems = append(problems, errors.New("tls.client_cert and tls.client_key must be set together"))
+	}
+
+	// Sort for stable output; map iteration order is random
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Error() < problems[j].Error() })
+	return errors.Join(problems...)
+}
+
+// itemType returns the type of the items of a list setting.
+func itemType(listType string) string {
+	if listType == TypeTools {
+		return TypeTool
+	}
+	return TypePath
+}
+
+// validateValue checks a value against a setting type and returns it normalized.
+func validateValue(valueType, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New("value cannot be empty (use unset to remove a key)")
+	}
+
+	switch valueType {
+	case TypeVersion:
+		return utils.NormalizeVersion(value)
+
+	case TypePath:
+		if !filepath.IsAbs(value) && value != "~" && !strings.HasPrefix(value, "~/") {
+			return "", fmt.Errorf("%q must be an absolute path or start with ~/", value)
+		}
+		return value, nil
+
+	case TypeURL:
+		u, err := url.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid URL: %w", value, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("%q must be an http:// or https:// URL", value)
+		}
+		return strings.TrimRight(value, "/"), nil
+
+	case TypeTool:
+		if err := utils.ValidateToolName(value); err != nil {
+			return "", err
+		}
+		return value, nil
+
+	case TypePolicy:
+		policy, err := ParsePolicy(value)
+		if err != nil {
+			return "", err
+		}
+		return string(policy), nil
+
+	default:
+		return value, nil
+	}
+}
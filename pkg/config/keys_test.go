@@ -0,0 +1,256 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupSetting(t *testing.T) {
+	tests := []struct {
+		key      string
+		wantKey  string
+		wantTool string
+		wantErr  bool
+	}{
+		{key: "mirrors.default", wantKey: "mirrors.default"},
+		{key: "defaults.terraform", wantKey: "defaults.<tool>", wantTool: "terraform"},
+		{key: "mirrors.tools.tofu", wantKey: "mirrors.tools.<tool>", wantTool: "tofu"},
+		{key: "verification.tools.terragrunt", wantKey: "verification.tools.<tool>", wantTool: "terragrunt"},
+		{key: "defaults.<tool>", wantErr: true},
+		{key: "defaults.", wantErr: true},
+		{key: "defaults.Terraform", wantErr: true},
+		{key: "mirrors.tools.a.b", wantErr: true},
+		{key: "mirrors.hosts.github.com", wantKey: "mirrors.hosts.<host>", wantTool: "github.com"},
+		{key: "mirrors.hosts.nexus.internal:8081", wantKey: "mirrors.hosts.<host>", wantTool: "nexus.internal:8081"},
+		{key: "mirrors.hosts.GitHub.com", wantErr: true},
+		{key: "mirrors.hosts.https://github.com", wantErr: true},
+		{key: "mirors.default", wantErr: true},
+		{key: "schema_version", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			setting, tool, err := LookupSetting(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupSetting(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if setting.Key != tt.wantKey || tool != tt.wantTool {
+				t.Errorf("LookupSetting(%q) = %q, %q; want %q, %q", tt.key, setting.Key, tool, tt.wantKey, tt.wantTool)
+			}
+		})
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    string // Value returned by Get afterwards
+		wantErr bool
+	}{
+		{name: "version is normalized", key: "defaults.terraform", value: "1.6.0", want: "v1.6.0"},
+		{name: "invalid version", key: "defaults.terraform", value: "latest", wantErr: true},
+		{name: "url", key: "mirrors.default", value: "https://mirror.example.com/releases/", want: "https://mirror.example.com/releases"},
+		{name: "url without scheme", key: "mirrors.tools.tofu", value: "mirror.example.com", wantErr: true},
+		{name: "ftp url", key: "mirrors.default", value: "ftp://mirror.example.com", wantErr: true},
+		{name: "home-relative path", key: "tls.ca_bundle", value: "~/certs/ca.pem", want: "~/certs/ca.pem"},
+		{name: "relative path", key: "paths.bin_dir", value: "bin", wantErr: true},
+		{name: "policy is normalized", key: "verification.tools.terraform", value: "Strict", want: "strict"},
+		{name: "invalid policy", key: "verification.default", value: "paranoid", wantErr: true},
+		{name: "path list", key: "gpg.key_files", value: "/etc/keys/a.asc, ~/b.asc", want: "/etc/keys/a.asc,~/b.asc"},
+		{name: "invalid path in list", key: "gpg.key_files", value: "/etc/keys/a.asc,b.asc", wantErr: true},
+		{name: "tool list", key: "tools.allowed", value: "terraform,tofu", want: "terraform,tofu"},
+		{name: "invalid tool in list", key: "tools.allowed", value: "Terraform", wantErr: true},
+		{name: "empty value", key: "mirrors.default", value: " ", wantErr: true},
+		{name: "unknown key", key: "mirrors.fallback", value: "https://example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := New()
+			err := cfg.Set(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, ok, err := cfg.Get(tt.key)
+			if err != nil || !ok {
+				t.Fatalf("Get(%q) = %q, %v, %v", tt.key, got, ok, err)
+			}
+			if got != tt.want {
+				t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigUnset(t *testing.T) {
+	cfg := New()
+	cfg.SetDefault("terraform", "v1.6.0")
+	cfg.GPG.KeyFiles = []string{"/etc/keys/a.asc"}
+
+	for _, key := range []string{"defaults.terraform", "gpg.key_files"} {
+		wasSet, err := cfg.Unset(key)
+		if err != nil || !wasSet {
+			t.Errorf("Unset(%q) = %v, %v; want true, nil", key, wasSet, err)
+		}
+		if _, ok, _ := cfg.Get(key); ok {
+			t.Errorf("Get(%q) after Unset reports a value", key)
+		}
+	}
+
+	if wasSet, err := cfg.Unset("mirrors.default"); err != nil || wasSet {
+		t.Errorf("Unset(unset key) = %v, %v; want false, nil", wasSet, err)
+	}
+
+	if _, err := cfg.Unset("nope"); err == nil {
+		t.Error("Unset(unknown key) should fail")
+	}
+}
+
+func TestConfigList(t *testing.T) {
+	cfg := New()
+	cfg.SetDefault("tofu", "v1.6.0")
+	cfg.SetDefault("terraform", "v1.5.0")
+	cfg.Mirrors.Default = "https://mirror.example.com"
+	cfg.GPG.KeyFiles = []string{"/a.asc", "/b.asc"}
+	cfg.Verification.Tools = map[string]string{"tofu": "checksum"}
+
+	want := []KeyValue{
+		{"defaults.terraform", "v1.5.0"},
+		{"defaults.tofu", "v1.6.0"},
+		{"mirrors.default", "https://mirror.example.com"},
+		{"gpg.key_files", "/a.asc,/b.asc"},
+		{"verification.tools.tofu", "checksum"},
+	}
+
+	if got := cfg.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Config)
+		wantErrs []string // Substrings expected in the error
+	}{
+		{
+			name:   "empty config",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "valid config",
+			modify: func(c *Config) {
+				c.SetDefault("terraform", "v1.6.0")
+				c.Mirrors.Tools = map[string]string{"terraform": "https://mirror.example.com"}
+				c.TLS.ClientCert = "/certs/client.pem"
+				c.TLS.ClientKey = "/certs/client.key"
+			},
+		},
+		{
+			name: "every problem is reported",
+			modify: func(c *Config) {
+				c.SetDefault("terraform", "latest")
+				c.Mirrors.Default = "not a url"
+				c.Verification.Tools = map[string]string{"Bad_Tool": "strict"}
+				c.TLS.ClientCert = "/certs/client.pem"
+				c.Locked = []string{"mirrors", "mirror"}
+			},
+			wantErrs: []string{"defaults.terraform", "mirrors.default", "verification.tools.Bad_Tool", "tls.client_key", `locked: unknown key "mirror"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := New()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if (err != nil) != (len(tt.wantErrs) > 0) {
+				t.Fatalf("Validate() error = %v, want errors %v", err, tt.wantErrs)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %v, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string // Substring of the expected error, "" for none
+	}{
+		{
+			name:    "valid",
+			content: "schema_version: 2\ndefaults:\n  terraform: v1.6.0\n",
+		},
+		{
+			name:    "empty file",
+			content: "",
+		},
+		{
+			name:    "misspelled section",
+			content: "schema_version: 2\nmirors:\n  default: https://mirror.example.com\n",
+			wantErr: "mirors",
+		},
+		{
+			name:    "invalid value",
+			content: "schema_version: 2\nverification:\n  default: paranoid\n",
+			wantErr: "verification.default",
+		},
+		{
+			name:    "syntax error",
+			content: "defaults: [\n",
+			wantErr: "failed to parse",
+		},
+		{
+			name:    "older schema is validated after migrating",
+			content: "defaults:\n  terraform: v1.6.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateData("config.yaml", []byte(tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateData() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateData() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "schema_version: 2\ndefaults:\n  terraform: v1.6.0\nmirors:\n  default: https://mirror.example.com\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(path); err != nil {
+		t.Errorf("Load() error = %v, want unknown keys ignored", err)
+	}
+	if _, err := LoadStrict(path); err == nil || !strings.Contains(err.Error(), "mirors") {
+		t.Errorf("LoadStrict() error = %v, want it to mention %q", err, "mirors")
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Layers holds the configuration files that make up the effective settings:
+// an admin-managed system config.yaml with the user's config.yaml on top.
+// Keys listed under 'locked' in the system config can't be overridden by the user.
+type Layers struct {
+	System     *Config // nil if there is no system config
+	SystemPath string
+	User       *Config // nil if there is no user config
+	UserPath   string
+}
+
+// LoadLayers loads the system and user configuration files. Missing files are
+// skipped; see Load for how each file is read.
+func LoadLayers(systemPath, userPath string) (*Layers, error) {
+	layers := &Layers{SystemPath: systemPath, UserPath: userPath}
+
+	var err error
+	if layers.System, err = loadIfExists(systemPath); err != nil {
+		return nil, err
+	}
+	if layers.User, err = loadIfExists(userPath); err != nil {
+		return nil, err
+	}
+
+	return layers, nil
+}
+
+func loadIfExists(path string) (*Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return Load(path)
+}
+
+// IsLocked reports whether the system config locks a key. A locked entry
+// covers the key itself and every key below it, so "mirrors" locks
+// mirrors.default, mirrors.tools.<tool>, and mirrors.hosts.<host>.
+func (l *Layers) IsLocked(key string) bool {
+	if l.System == nil {
+		return false
+	}
+	for _, locked := range l.System.Locked {
+		if key == locked || strings.HasPrefix(key, locked+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Merged returns the effective configuration: user values override system
+// values, except for locked keys. Lists such as gpg.key_files are replaced
+// as a whole, not appended to.
+func (l *Layers) Merged() *Config {
+	merged := New()
+	if l.System != nil {
+		merged.Locked = append([]string(nil), l.System.Locked...)
+	}
+
+	for _, layer := range []*Config{l.System, l.User} {
+		if layer == nil {
+			continue
+		}
+		// Only the user layer is subject to locks
+		overridable := func(key string) bool { return layer != l.User || !l.IsLocked(key) }
+
+		for _, setting := range settings {
+			switch {
+			case setting.table != nil:
+				for tool, value := range *setting.table(layer) {
+					key := setting.entryKey(tool)
+					if value == "" || !overridable(key) {
+						continue
+					}
+					table := setting.table(merged)
+					if *table == nil {
+						*table = make(map[string]string)
+					}
+					(*table)[tool] = value
+				}
+			case setting.list != nil:
+				if values := *setting.list(layer); len(values) > 0 && overridable(setting.Key) {
+					*setting.list(merged) = append([]string(nil), values...)
+				}
+			default:
+				if value := *setting.field(layer); value != "" && overridable(setting.Key) {
+					*setting.field(merged) = value
+				}
+			}
+		}
+	}
+
+	return merged
+}
+
+// Origin returns the path of the file the effective value of a key comes
+// from, or "" if the key isn't set.
+func (l *Layers) Origin(key string) string {
+	if l.User != nil && !l.IsLocked(key) {
+		if _, ok, _ := l.User.Get(key); ok {
+			return l.UserPath
+		}
+	}
+	if l.System != nil {
+		if _, ok, _ := l.System.Get(key); ok {
+			return l.SystemPath
+		}
+	}
+	return ""
+}
+
+// Ignored returns the user settings that have no effect because the system
+// config locks them.
+func (l *Layers) Ignored() []KeyValue {
+	if l.User == nil {
+		return nil
+	}
+
+	var ignored []KeyValue
+	for _, kv := range l.User.List() {
+		if l.IsLocked(kv.Key) {
+			ignored = append(ignored, kv)
+		}
+	}
+	return ignored
+}
+
+// IsLockable reports whether a 'locked' entry names a setting or a section of
+// settings, such as "verification.default", "mirrors" or "mirrors.tools".
+func IsLockable(entry string) bool {
+	if _, _, err := LookupSetting(entry); err == nil {
+		return true
+	}
+	for _, setting := range settings {
+		if strings.HasPrefix(setting.Key, entry+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadLayers(t *testing.T) {
+	dir := t.TempDir()
+	systemPath := filepath.Join(dir, "system.yaml")
+	userPath := filepath.Join(dir, "user.yaml")
+
+	layers, err := LoadLayers(systemPath, userPath)
+	if err != nil {
+		t.Fatalf("LoadLayers() with no files error = %v", err)
+	}
+	if layers.System != nil || layers.User != nil {
+		t.Errorf("LoadLayers() with no files = %+v, want empty layers", layers)
+	}
+
+	system := "schema_version: 2\nmirrors:\n  default: https://mirror.example.com\nlocked:\n  - mirrors\n"
+	if err := os.WriteFile(systemPath, []byte(system), 0644); err != nil {
+		t.Fatalf("failed to write system config: %v", err)
+	}
+
+	layers, err = LoadLayers(systemPath, userPath)
+	if err != nil {
+		t.Fatalf("LoadLayers() error = %v", err)
+	}
+	if layers.System == nil || layers.System.Mirrors.Default != "https://mirror.example.com" {
+		t.Errorf("LoadLayers() System = %+v", layers.System)
+	}
+	if layers.User != nil {
+		t.Errorf("LoadLayers() User = %+v, want nil", layers.User)
+	}
+}
+
+func TestLayersMerged(t *testing.T) {
+	system := New()
+	system.Mirrors.Default = "https://mirror.example.com"
+	system.Mirrors.Tools = map[string]string{"tofu": "https://github-mirror.example.com"}
+	system.Verification.Default = "strict"
+	system.Tools.Allowed = []string{"terraform", "tofu"}
+	system.GPG.KeyFiles = []string{"/etc/binarius/keys/hashicorp.asc"}
+	system.Locked = []string{"mirrors", "tools.allowed"}
+
+	user := New()
+	user.SetDefault("terraform", "v1.6.0")
+	user.Mirrors.Default = "https://other.example.com"
+	user.Mirrors.Tools = map[string]string{"terraform": "https://other.example.com"}
+	user.Verification.Default = "checksum"
+	user.Tools.Allowed = []string{"terragrunt"}
+	user.GPG.KeyFiles = []string{"~/keys/internal.asc"}
+
+	layers := &Layers{System: system, SystemPath: "/etc/binarius/config.yaml", User: user, UserPath: "/home/u/.binarius/config.yaml"}
+	merged := layers.Merged()
+
+	tests := []struct {
+		key        string
+		wantValue  string
+		wantOrigin string
+	}{
+		{"defaults.terraform", "v1.6.0", layers.UserPath},
+		{"mirrors.default", "https://mirror.example.com", layers.SystemPath},
+		{"mirrors.tools.tofu", "https://github-mirror.example.com", layers.SystemPath},
+		{"mirrors.tools.terraform", "", ""},
+		{"verification.default", "checksum", layers.UserPath},
+		{"tools.allowed", "terraform,tofu", layers.SystemPath},
+		{"gpg.key_files", "~/keys/internal.asc", layers.UserPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got, _, _ := merged.Get(tt.key); got != tt.wantValue {
+				t.Errorf("Merged().Get(%q) = %q, want %q", tt.key, got, tt.wantValue)
+			}
+			if got := layers.Origin(tt.key); got != tt.wantOrigin {
+				t.Errorf("Origin(%q) = %q, want %q", tt.key, got, tt.wantOrigin)
+			}
+		})
+	}
+
+	wantIgnored := []KeyValue{
+		{"mirrors.default", "https://other.example.com"},
+		{"mirrors.tools.terraform", "https://other.example.com"},
+		{"tools.allowed", "terragrunt"},
+	}
+	if got := layers.Ignored(); !reflect.DeepEqual(got, wantIgnored) {
+		t.Errorf("Ignored() = %v, want %v", got, wantIgnored)
+	}
+
+	// Merging must not modify the layers themselves
+	if user.Mirrors.Default != "https://other.example.com" || len(system.Mirrors.Tools) != 1 {
+		t.Error("Merged() modified a layer")
+	}
+}
+
+func TestLayersIsLocked(t *testing.T) {
+	layers := &Layers{System: &Config{Locked: []string{"mirrors", "verification.default"}}}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"mirrors.default", true},
+		{"mirrors.tools.tofu", true},
+		{"verification.default", true},
+		{"verification.tools.tofu", false},
+		{"mirrorsx.default", false},
+	}
+
+	for _, tt := range tests {
+		if got := layers.IsLocked(tt.key); got != tt.want {
+			t.Errorf("IsLocked(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+
+	if (&Layers{User: New()}).IsLocked("mirrors.default") {
+		t.Error("IsLocked() without a system config should be false")
+	}
+}
+
+func TestIsLockable(t *testing.T) {
+	for entry, want := range map[string]bool{
+		"mirrors":              true,
+		"mirrors.tools":        true,
+		"mirrors.tools.tofu":   true,
+		"verification.default": true,
+		"tools.allowed":        true,
+		"mirror":               false,
+		"schema_version":       false,
+	} {
+		if got := IsLockable(entry); got != want {
+			t.Errorf("IsLockable(%q) = %v, want %v", entry, got, want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Schema versions of the files written by this version of Binarius.
+// When the layout of a file changes, bump its version and append a Migration
+// that upgrades documents from the previous version.
+const (
+	RegistrySchemaVersion = 1 // installation.json
+	ConfigSchemaVersion   = 2 // config.yaml
+)
+
+// Migration upgrades a decoded document from schema version From to From+1.
+// Apply receives the document as a generic map so that it can read layouts
+// the current structs no longer describe.
+type Migration struct {
+	From        int
+	Description string
+	Apply       func(doc map[string]interface{}) error
+}
+
+// registryMigrations upgrade installation.json, ordered by From.
+var registryMigrations = []Migration{
+	{
+		From:        0,
+		Description: "record tool name, version and status in every entry",
+		Apply:       migrateRegistryV0,
+	},
+}
+
+// configMigrations upgrade config.yaml, ordered by From.
+var configMigrations = []Migration{
+	{
+		From:        0,
+		Description: "add schema_version",
+		Apply:       func(doc map[string]interface{}) error { return nil },
+	},
+	{
+		From:        1,
+		Description: "drop paths that only restate the defaults written by 'binarius init'",
+		Apply:       migrateConfigV1,
+	},
+}
+
+// SchemaError reports a file whose schema version is newer than this version
+// of Binarius supports, which means a newer Binarius wrote it.
+type SchemaError struct {
+	Path      string
+	Version   int // Schema version found in the file
+	Supported int // Newest schema version this Binarius can read
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s has schema version %d, but this version of Binarius only supports up to %d (it was written by a newer Binarius)",
+		e.Path, e.Version, e.Supported)
+}
+
+// IsNewerSchema reports whether err was caused by a file written by a newer Binarius.
+func IsNewerSchema(err error) bool {
+	var schemaErr *SchemaError
+	return errors.As(err, &schemaErr)
+}
+
+// migrate upgrades doc in place to the current schema version by applying
+// migrations in order. Returns the schema version doc had before migrating.
+func migrate(path string, doc map[string]interface{}, current int, migrations []Migration) (int, error) {
+	from, err := schemaVersion(doc)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if from > current {
+		return from, &SchemaError{Path: path, Version: from, Supported: current}
+	}
+
+	for version := from; version < current; version++ {
+		migration, ok := findMigration(migrations, version)
+		if !ok {
+			return from, fmt.Errorf("no migration for %s from schema version %d", path, version)
+		}
+
+		if err := migration.Apply(doc); err != nil {
+			return from, fmt.Errorf("failed to migrate %s from schema version %d (%s): %w", path, version, migration.Description, err)
+		}
+		doc["schema_version"] = version + 1
+	}
+
+	return from, nil
+}
+
+func findMigration(migrations []Migration, from int) (Migration, bool) {
+	for _, migration := range migrations {
+		if migration.From == from {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// schemaVersion reads the schema_version field of a decoded document.
+// Files written before schema versions were recorded are version 0.
+func schemaVersion(doc map[string]interface{}) (int, error) {
+	value, ok := doc["schema_version"]
+	if !ok || value == nil {
+		return 0, nil
+	}
+
+	var version int
+	switch v := value.(type) {
+	case int:
+		version = v
+	case float64:
+		version = int(v)
+		if float64(version) != v {
+			return 0, fmt.Errorf("invalid schema_version %v", v)
+		}
+	case json.Number:
+		n, err := strconv.Atoi(v.String())
+		if err != nil {
+			return 0, fmt.Errorf("invalid schema_version %s", v)
+		}
+		version = n
+	default:
+		return 0, fmt.Errorf("invalid schema_version %v", v)
+	}
+
+	if version < 0 {
+		return 0, fmt.Errorf("invalid schema_version %d", version)
+	}
+	return version, nil
+}
+
+// backupFile writes the original contents of a file about to be migrated to
+// "<path>.bak-v<version>". An existing backup is kept, so the oldest copy of
+// each schema version survives repeated or concurrent migrations.
+func backupFile(path string, version int, data []byte) (string, error) {
+	backupPath := fmt.Sprintf("%s.bak-v%d", path, version)
+
+	file, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return backupPath, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup %s: %w", backupPath, err)
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(backupPath)
+		return "", fmt.Errorf("failed to write backup %s: %w", backupPath, err)
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(backupPath)
+		return "", fmt.Errorf("failed to write backup %s: %w", backupPath, err)
+	}
+
+	return backupPath, nil
+}
+
+// migrateRegistryV0 fills in the tool name, version, and status of entries
+// written before they were recorded in each entry.
+func migrateRegistryV0(doc map[string]interface{}) error {
+	toolsValue, ok := doc["tools"]
+	if !ok || toolsValue == nil {
+		return nil
+	}
+
+	toolsMap, ok := toolsValue.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("tools is not an object")
+	}
+
+	for toolName, versionsValue := range toolsMap {
+		versions, ok := versionsValue.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("versions of %s are not an object", toolName)
+		}
+
+		for version, entryValue := range versions {
+			entry, ok := entryValue.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("entry %s@%s is not an object", toolName, version)
+			}
+
+			if name, _ := entry["tool_name"].(string); name == "" {
+				entry["tool_name"] = toolName
+			}
+			if v, _ := entry["version"].(string); v == "" {
+				entry["version"] = version
+			}
+			if status, _ := entry["status"].(string); status == "" {
+				entry["status"] = StatusComplete
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigV1 removes paths entries that equal the defaults. Before schema
+// version 2, paths were written by 'binarius init' but never read, so a default
+// value there is a leftover, not a choice. Keeping it would now pin the cache to
+// ~/.binarius/cache even when BINARIUS_HOME points elsewhere.
+func migrateConfigV1(doc map[string]interface{}) error {
+	pathsValue, ok := doc["paths"]
+	if !ok || pathsValue == nil {
+		return nil
+	}
+
+	pathsMap, ok := pathsValue.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("paths is not a mapping")
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	defaults := map[string]string{
+		"binarius_home": filepath.Join(homeDir, ".binarius"),
+		"bin_dir":       filepath.Join(homeDir, ".local", "bin"),
+		"cache_dir":     filepath.Join(homeDir, ".binarius", "cache"),
+	}
+
+	for key, defaultValue := range defaults {
+		if value, _ := pathsMap[key].(string); value == "" || filepath.Clean(value) == defaultValue {
+			delete(pathsMap, key)
+		}
+	}
+
+	if len(pathsMap) == 0 {
+		delete(doc, "paths")
+	}
+
+	return nil
+}
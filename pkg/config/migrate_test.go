@@ -0,0 +1,377 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadRegistry_Migration(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantErr    bool
+		wantNewer  bool
+		wantBackup bool
+		validate   func(*testing.T, *Registry)
+	}{
+		{
+			name: "unversioned registry is migrated",
+			content: `{
+  "tools": {
+    "terraform": {
+      "v1.6.0": {"binary_path": "/tools/terraform/v1.6.0/terraform", "size_bytes": 25678901}
+    }
+  }
+}`,
+			wantBackup: true,
+			validate: func(t *testing.T, r *Registry) {
+				tv := r.GetVersion("terraform", "v1.6.0")
+				if tv.ToolName != "terraform" || tv.Version != "v1.6.0" {
+					t.Errorf("entry identity = %q@%q, want terraform@v1.6.0", tv.ToolName, tv.Version)
+				}
+				if tv.Status != StatusComplete {
+					t.Errorf("Status = %q, want %q", tv.Status, StatusComplete)
+				}
+				if tv.SizeBytes != 25678901 {
+					t.Errorf("SizeBytes = %d, want 25678901", tv.SizeBytes)
+				}
+			},
+		},
+		{
+			name:       "migration keeps recorded status",
+			content:    `{"tools": {"tofu": {"v1.6.0": {"binary_path": "/tools/tofu/v1.6.0/tofu", "status": "broken"}}}}`,
+			wantBackup: true,
+			validate: func(t *testing.T, r *Registry) {
+				if status := r.GetVersion("tofu", "v1.6.0").Status; status != StatusBroken {
+					t.Errorf("Status = %q, want %q", status, StatusBroken)
+				}
+			},
+		},
+		{
+			name:    "current registry is loaded as is",
+			content: `{"schema_version": 1, "tools": {}}`,
+		},
+		{
+			name:      "newer registry is rejected",
+			content:   `{"schema_version": 99, "tools": {}}`,
+			wantErr:   true,
+			wantNewer: true,
+		},
+		{
+			name:    "invalid schema version",
+			content: `{"schema_version": "one"}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed tools",
+			content: `{"tools": []}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "installation.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write registry: %v", err)
+			}
+
+			registry, err := LoadRegistry(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsNewerSchema(err) != tt.wantNewer {
+				t.Errorf("IsNewerSchema(%v) = %v, want %v", err, !tt.wantNewer, tt.wantNewer)
+			}
+			if err != nil {
+				return
+			}
+
+			if registry.SchemaVersion != RegistrySchemaVersion {
+				t.Errorf("SchemaVersion = %d, want %d", registry.SchemaVersion, RegistrySchemaVersion)
+			}
+
+			// Loading migrates in memory only
+			if data, err := os.ReadFile(path); err != nil || string(data) != tt.content {
+				t.Errorf("LoadRegistry() changed the file: %q", data)
+			}
+			if _, err := os.Stat(path + ".bak-v0"); err == nil {
+				t.Error("LoadRegistry() wrote a backup")
+			}
+
+			if tt.validate != nil {
+				tt.validate(t, registry)
+			}
+
+			migrated, err := MigrateRegistryFile(path)
+			if err != nil {
+				t.Fatalf("MigrateRegistryFile() error = %v", err)
+			}
+			if migrated != tt.wantBackup {
+				t.Errorf("MigrateRegistryFile() = %v, want %v", migrated, tt.wantBackup)
+			}
+
+			backup, err := os.ReadFile(path + ".bak-v0")
+			if tt.wantBackup {
+				if err != nil {
+					t.Fatalf("Expected backup of the original registry: %v", err)
+				}
+				if string(backup) != tt.content {
+					t.Errorf("Backup = %q, want original content", backup)
+				}
+
+				// The upgraded registry is written back
+				reloaded, err := LoadRegistry(path)
+				if err != nil {
+					t.Fatalf("LoadRegistry() after migration error = %v", err)
+				}
+				if reloaded.SchemaVersion != RegistrySchemaVersion {
+					t.Errorf("Written SchemaVersion = %d, want %d", reloaded.SchemaVersion, RegistrySchemaVersion)
+				}
+				if tt.validate != nil {
+					tt.validate(t, reloaded)
+				}
+			} else if err == nil {
+				t.Error("Unexpected backup of a current registry")
+			}
+		})
+	}
+}
+
+func TestMigrateRegistryFile_Missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "installation.json")
+	migrated, err := MigrateRegistryFile(path)
+	if err != nil || migrated {
+		t.Errorf("MigrateRegistryFile() = %v, %v; want false, nil", migrated, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("MigrateRegistryFile() created a missing registry")
+	}
+}
+
+// Unparseable files are left for repair or 'config edit' to fix, so they
+// mustn't stop the home lock from being taken.
+func TestMigrateFiles_Unparseable(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name    string
+		migrate func(string) (bool, error)
+	}{
+		{"installation.json", MigrateRegistryFile},
+		{"config.yaml", MigrateConfigFile},
+	} {
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, []byte("{not: [valid"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		migrated, err := tt.migrate(path)
+		if err != nil || migrated {
+			t.Errorf("migrating unparseable %s = %v, %v; want false, nil", tt.name, migrated, err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "{not: [valid" {
+			t.Errorf("migrating unparseable %s changed it to %q", tt.name, data)
+		}
+	}
+}
+
+func TestLoad_Migration(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantErr    bool
+		wantNewer  bool
+		wantBackup bool
+	}{
+		{
+			name:       "unversioned config is migrated",
+			content:    "defaults:\n  terraform: v1.6.0\n",
+			wantBackup: true,
+		},
+		{
+			name:    "current config is loaded as is",
+			content: "schema_version: 2\ndefaults:\n  terraform: v1.6.0\n",
+		},
+		{
+			name:      "newer config is rejected",
+			content:   "schema_version: 3\ndefaults: {}\n",
+			wantErr:   true,
+			wantNewer: true,
+		},
+		{
+			name:    "negative schema version",
+			content: "schema_version: -1\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsNewerSchema(err) != tt.wantNewer {
+				t.Errorf("IsNewerSchema(%v) = %v, want %v", err, !tt.wantNewer, tt.wantNewer)
+			}
+			if err != nil {
+				return
+			}
+
+			if cfg.SchemaVersion != ConfigSchemaVersion {
+				t.Errorf("SchemaVersion = %d, want %d", cfg.SchemaVersion, ConfigSchemaVersion)
+			}
+			if cfg.GetDefault("terraform") != "v1.6.0" {
+				t.Errorf("GetDefault(terraform) = %q, want v1.6.0", cfg.GetDefault("terraform"))
+			}
+
+			// Loading migrates in memory only
+			if data, err := os.ReadFile(path); err != nil || string(data) != tt.content {
+				t.Errorf("Load() changed the file: %q", data)
+			}
+
+			migrated, err := MigrateConfigFile(path)
+			if err != nil {
+				t.Fatalf("MigrateConfigFile() error = %v", err)
+			}
+			if migrated != tt.wantBackup {
+				t.Errorf("MigrateConfigFile() = %v, want %v", migrated, tt.wantBackup)
+			}
+
+			_, err = os.Stat(path + ".bak-v0")
+			if tt.wantBackup != (err == nil) {
+				t.Errorf("Backup exists = %v, want %v", err == nil, tt.wantBackup)
+			}
+
+			if tt.wantBackup {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("Failed to read migrated config: %v", err)
+				}
+				if !strings.Contains(string(data), fmt.Sprintf("schema_version: %d", ConfigSchemaVersion)) {
+					t.Errorf("Migrated config doesn't record schema_version:\n%s", data)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateConfigV1(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("Failed to get user home directory: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    PathConfig
+	}{
+		{
+			name: "defaults written by init are dropped",
+			content: fmt.Sprintf("schema_version: 1\npaths:\n  binarius_home: %s\n  bin_dir: %s\n  cache_dir: %s\n",
+				filepath.Join(homeDir, ".binarius"), filepath.Join(homeDir, ".local", "bin"), filepath.Join(homeDir, ".binarius", "cache")),
+			want: PathConfig{},
+		},
+		{
+			name: "customized paths are kept",
+			content: fmt.Sprintf("schema_version: 1\npaths:\n  binarius_home: /data/binarius\n  bin_dir: %s\n  cache_dir: /var/cache/binarius\n",
+				filepath.Join(homeDir, ".local", "bin")),
+			want: PathConfig{BinariusHome: "/data/binarius", CacheDir: "/var/cache/binarius"},
+		},
+		{
+			name:    "no paths",
+			content: "schema_version: 1\n",
+			want:    PathConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if cfg.Paths != tt.want {
+				t.Errorf("Paths = %+v, want %+v", cfg.Paths, tt.want)
+			}
+
+			if _, err := MigrateConfigFile(path); err != nil {
+				t.Fatalf("MigrateConfigFile() error = %v", err)
+			}
+			if _, err := os.Stat(path + ".bak-v1"); err != nil {
+				t.Errorf("Expected backup of the schema version 1 config: %v", err)
+			}
+
+			migrated, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() after migration error = %v", err)
+			}
+			if migrated.Paths != tt.want {
+				t.Errorf("Migrated paths = %+v, want %+v", migrated.Paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrateConfigFile_KeepsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("defaults:\n  terraform: v1.6.0\nmirrorz:\n  default: https://typo.example.com\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	migrated, err := MigrateConfigFile(path)
+	if err != nil || !migrated {
+		t.Fatalf("MigrateConfigFile() = %v, %v; want true, nil", migrated, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read migrated config: %v", err)
+	}
+	if !strings.Contains(string(data), "mirrorz:") {
+		t.Errorf("Migrated config dropped an unknown key:\n%s", data)
+	}
+	if !strings.Contains(string(data), fmt.Sprintf("schema_version: %d", ConfigSchemaVersion)) {
+		t.Errorf("Migrated config doesn't record schema_version:\n%s", data)
+	}
+}
+
+func TestBackupFile_KeepsExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "installation.json")
+
+	first, err := backupFile(path, 0, []byte("original"))
+	if err != nil {
+		t.Fatalf("backupFile() error = %v", err)
+	}
+
+	second, err := backupFile(path, 0, []byte("later"))
+	if err != nil {
+		t.Fatalf("backupFile() error = %v", err)
+	}
+
+	if first != second {
+		t.Errorf("backup paths differ: %q and %q", first, second)
+	}
+
+	data, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatalf("Failed to read backup: %v", err)
+	}
+	if string(data) != "original" {
+		t.Errorf("Backup = %q, want %q", data, "original")
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy controls which verifiers an installer.Pipeline runs and how strictly.
+type Policy string
+
+const (
+	// PolicyDefault runs every verifier; verifiers that aren't Required are
+	// skipped with a warning when their material isn't available.
+	PolicyDefault Policy = ""
+	// PolicyStrict runs every verifier and fails if any of them can't run.
+	PolicyStrict Policy = "strict"
+	// PolicyChecksum runs checksum verifiers only and skips signatures.
+	PolicyChecksum Policy = "checksum"
+	// PolicyNone skips verification entirely.
+	PolicyNone Policy = "none"
+)
+
+// ParsePolicy validates a verification policy name from config.yaml.
+func ParsePolicy(name string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(name))); p {
+	case PolicyDefault, PolicyStrict, PolicyChecksum, PolicyNone:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid verification policy %q: must be strict, checksum, or none", name)
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+// TestParsePolicy verifies verification policy parsing.
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Policy
+		wantErr bool
+	}{
+		{input: "", want: PolicyDefault},
+		{input: "strict", want: PolicyStrict},
+		{input: "Checksum", want: PolicyChecksum},
+		{input: " none ", want: PolicyNone},
+		{input: "paranoid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePolicy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePolicy(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
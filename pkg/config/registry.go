@@ -1,41 +1,71 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 )
 
 // ToolVersion represents metadata for a single installed tool version.
+// The yaml tags are used by --output yaml and match the json tags.
 type ToolVersion struct {
-	ToolName     string    `json:"tool_name,omitempty"`
-	Version      string    `json:"version,omitempty"`
-	BinaryPath   string    `json:"binary_path"`
-	InstalledAt  time.Time `json:"installed_at,omitempty"`
-	SizeBytes    int64     `json:"size_bytes,omitempty"`
-	SourceURL    string    `json:"source_url,omitempty"`
-	Checksum     string    `json:"checksum,omitempty"`
-	Architecture string    `json:"architecture,omitempty"`
-	Status       string    `json:"status,omitempty"` // "complete", "partial", or "broken"
+	ToolName       string    `json:"tool_name,omitempty" yaml:"tool_name,omitempty"`
+	Version        string    `json:"version,omitempty" yaml:"version,omitempty"`
+	BinaryPath     string    `json:"binary_path" yaml:"binary_path"`
+	InstalledAt    time.Time `json:"installed_at,omitempty" yaml:"installed_at,omitempty"`
+	SizeBytes      int64     `json:"size_bytes,omitempty" yaml:"size_bytes,omitempty"`
+	SourceURL      string    `json:"source_url,omitempty" yaml:"source_url,omitempty"`
+	Checksum       string    `json:"checksum,omitempty" yaml:"checksum,omitempty"`               // SHA256 of the downloaded archive
+	BinaryChecksum string    `json:"binary_checksum,omitempty" yaml:"binary_checksum,omitempty"` // SHA256 of the extracted binary, checked by 'binarius verify'
+	Architecture   string    `json:"architecture,omitempty" yaml:"architecture,omitempty"`
+	Status         string    `json:"status,omitempty" yaml:"status,omitempty"`             // "complete", "partial", or "broken"
+	Verification   []string  `json:"verification,omitempty" yaml:"verification,omitempty"` // Checks passed at install time, e.g. "sha256", "gpg"
+}
+
+// Installation statuses recorded in ToolVersion.Status.
+const (
+	StatusComplete = "complete" // Installed and intact
+	StatusPartial  = "partial"  // Installation did not finish
+	StatusBroken   = "broken"   // Binary missing or modified after installation
+)
+
+// IsComplete reports whether the version finished installing and hasn't been flagged as broken.
+// Entries written before statuses were recorded have an empty status and count as complete.
+func (tv ToolVersion) IsComplete() bool {
+	return tv.Status == "" || tv.Status == StatusComplete
+}
+
+// StatusOrDefault returns the version's status, treating an empty status as complete.
+func (tv ToolVersion) StatusOrDefault() string {
+	if tv.Status == "" {
+		return StatusComplete
+	}
+	return tv.Status
 }
 
 // Registry represents the installation registry that tracks all installed tool versions.
 // Structure: map[toolName]map[version]ToolVersion
 type Registry struct {
-	Tools map[string]map[string]ToolVersion `json:"tools,omitempty"`
+	SchemaVersion int                               `json:"schema_version"` // Layout version, see RegistrySchemaVersion
+	Tools         map[string]map[string]ToolVersion `json:"tools,omitempty"`
 }
 
 // NewRegistry creates a new empty Registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		Tools: make(map[string]map[string]ToolVersion),
+		SchemaVersion: RegistrySchemaVersion,
+		Tools:         make(map[string]map[string]ToolVersion),
 	}
 }
 
 // LoadRegistry reads and parses the installation registry from the specified path.
 // Returns an empty registry if the file doesn't exist.
+//
+// Registries written with an older schema are migrated to RegistrySchemaVersion
+// in memory; the file itself is only upgraded by MigrateRegistryFile. A
+// registry written by a newer Binarius returns a *SchemaError.
 func LoadRegistry(path string) (*Registry, error) {
 	// Check if file exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -48,8 +78,20 @@ func LoadRegistry(path string) (*Registry, error) {
 		return nil, fmt.Errorf("failed to read registry file %s: %w", path, err)
 	}
 
+	doc, from, err := decodeRegistry(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := data
+	if from < RegistrySchemaVersion {
+		if migrated, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate registry file %s: %w", path, err)
+		}
+	}
+
 	var registry Registry
-	if err := json.Unmarshal(data, &registry); err != nil {
+	if err := json.Unmarshal(migrated, &registry); err != nil {
 		return nil, fmt.Errorf("failed to parse registry file %s: %w", path, err)
 	}
 
@@ -61,30 +103,78 @@ func LoadRegistry(path string) (*Registry, error) {
 	return &registry, nil
 }
 
+// MigrateRegistryFile upgrades the registry at path to RegistrySchemaVersion
+// in place, keeping the original as "<path>.bak-v<old version>". Missing and
+// current files, and files that can't be parsed, are left alone; LoadRegistry
+// reports the latter. Reports whether the file was migrated.
+//
+// Callers must hold the lock on the Binarius home directory.
+func MigrateRegistryFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read registry file %s: %w", path, err)
+	}
+
+	doc, from, err := decodeRegistry(path, data)
+	if IsNewerSchema(err) {
+		return false, err
+	}
+	if err != nil || from == RegistrySchemaVersion {
+		// Leave files that can't be parsed for repair or editing
+		return false, nil
+	}
+
+	migrated, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to migrate registry file %s: %w", path, err)
+	}
+	if _, err := backupFile(path, from, data); err != nil {
+		return false, err
+	}
+	if err := writeFileAtomic(path, migrated, 0644); err != nil {
+		return false, fmt.Errorf("failed to save registry file: %w", err)
+	}
+
+	return true, nil
+}
+
+// decodeRegistry decodes installation.json contents generically, so migrations
+// can handle layouts Registry no longer describes, and migrates them to the
+// current schema. Returns the schema version the data had before migrating.
+func decodeRegistry(path string, data []byte) (map[string]interface{}, int, error) {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse registry file %s: %w", path, err)
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+
+	from, err := migrate(path, doc, RegistrySchemaVersion, registryMigrations)
+	if err != nil {
+		return nil, from, err
+	}
+	return doc, from, nil
+}
+
 // SaveRegistry writes the registry to the specified path using atomic write pattern.
+// The registry is always written with the current schema version.
 func SaveRegistry(registry *Registry, path string) error {
+	registry.SchemaVersion = RegistrySchemaVersion
+
 	// Marshal registry to JSON with indentation for readability
 	data, err := json.MarshalIndent(registry, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal registry: %w", err)
 	}
 
-	// Ensure parent directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create registry directory %s: %w", dir, err)
-	}
-
-	// Write to temporary file
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary registry file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, path); err != nil {
-		// Clean up temporary file on failure
-		_ = os.Remove(tmpPath)
+	// Write via uniquely named temporary file and atomic rename
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to save registry file: %w", err)
 	}
 
@@ -170,6 +260,12 @@ func (r *Registry) HasVersion(toolName, version string) bool {
 }
 
 // IsInstalled checks if a specific tool version is installed (alias for HasVersion).
+// Partial and broken installations count as installed; use IsUsable to exclude them.
 func (r *Registry) IsInstalled(toolName, version string) bool {
 	return r.HasVersion(toolName, version)
 }
+
+// IsUsable checks if a specific tool version is installed and complete.
+func (r *Registry) IsUsable(toolName, version string) bool {
+	return r.HasVersion(toolName, version) && r.GetVersion(toolName, version).IsComplete()
+}
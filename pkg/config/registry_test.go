@@ -2,8 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -601,6 +604,43 @@ func TestHasVersion(t *testing.T) {
 	}
 }
 
+// TestIsUsable verifies that only complete installations are usable.
+func TestIsUsable(t *testing.T) {
+	registry := &Registry{
+		Tools: map[string]map[string]ToolVersion{
+			"terraform": {
+				"v1.6.0": {Version: "v1.6.0", Status: StatusComplete},
+				"v1.5.0": {Version: "v1.5.0"}, // written before statuses were recorded
+				"v1.4.0": {Version: "v1.4.0", Status: StatusPartial},
+				"v1.3.0": {Version: "v1.3.0", Status: StatusBroken},
+			},
+		},
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "v1.6.0", want: true},
+		{version: "v1.5.0", want: true},
+		{version: "v1.4.0", want: false},
+		{version: "v1.3.0", want: false},
+		{version: "v1.2.0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := registry.IsUsable("terraform", tt.version); got != tt.want {
+				t.Errorf("IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := registry.GetVersion("terraform", "v1.5.0").StatusOrDefault(); got != StatusComplete {
+		t.Errorf("StatusOrDefault() = %q, want %q", got, StatusComplete)
+	}
+}
+
 func TestSaveRegistry_AtomicWrite(t *testing.T) {
 	tempDir := t.TempDir()
 	registryPath := filepath.Join(tempDir, "installation.json")
@@ -634,6 +674,9 @@ func TestSaveRegistry_AtomicWrite(t *testing.T) {
 	if _, err := os.Stat(tmpPath); !os.IsNotExist(err) {
 		t.Error("SaveRegistry() atomic write left temporary file")
 	}
+	if matches, _ := filepath.Glob(registryPath + ".tmp*"); len(matches) > 0 {
+		t.Errorf("SaveRegistry() atomic write left temporary files: %v", matches)
+	}
 
 	// Verify final content
 	loaded, err := LoadRegistry(registryPath)
@@ -649,3 +692,43 @@ func TestSaveRegistry_AtomicWrite(t *testing.T) {
 		t.Error("Atomic write failed: v1.5.0 should be overwritten")
 	}
 }
+
+func TestSaveRegistry_ConcurrentWriters(t *testing.T) {
+	tempDir := t.TempDir()
+	registryPath := filepath.Join(tempDir, "installation.json")
+
+	// Concurrent writers must never fail because they share a temporary file
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			registry := NewRegistry()
+			registry.AddVersion("terraform", fmt.Sprintf("v1.%d.0", i), ToolVersion{Status: "complete"})
+			errs <- SaveRegistry(registry, registryPath)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("SaveRegistry() concurrent write error = %v", err)
+		}
+	}
+
+	if _, err := LoadRegistry(registryPath); err != nil {
+		t.Errorf("LoadRegistry() after concurrent writes error = %v", err)
+	}
+}
+
+func TestToolVersion_YAMLTagsMatchJSON(t *testing.T) {
+	typ := reflect.TypeOf(ToolVersion{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if jsonTag, yamlTag := field.Tag.Get("json"), field.Tag.Get("yaml"); jsonTag != yamlTag {
+			t.Errorf("ToolVersion.%s: yaml tag %q differs from json tag %q", field.Name, yamlTag, jsonTag)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package doctor diagnoses problems with a Binarius installation: PATH
+// configuration, symlinks, the installation registry, and directory permissions.
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+)
+
+// Severity classifies how serious a finding is.
+type Severity int
+
+const (
+	// Warning is a problem that doesn't stop Binarius from working.
+	Warning Severity = iota
+	// Error is a problem that breaks installed tools or Binarius itself.
+	Error
+)
+
+// String returns the severity name.
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Finding is a single problem detected by a check, described in UserError style.
+type Finding struct {
+	Severity Severity
+	Problem  *utils.UserError
+}
+
+func newFinding(severity Severity, context, reason, action string) Finding {
+	return Finding{Severity: severity, Problem: utils.NewUserError(context, reason, action)}
+}
+
+// InPath reports whether dir is one of the directories in a PATH-style list.
+// Entries are compared as cleaned absolute paths, not substrings.
+func InPath(dir, pathEnv string) bool {
+	return pathIndex(dir, filepath.SplitList(pathEnv)) >= 0
+}
+
+// CheckPath checks that binDir is in PATH and that no other directory earlier
+// in PATH provides one of the managed tools, which would shadow Binarius' symlink.
+//
+// Parameters:
+//   - binDir: The directory holding Binarius' symlinks
+//   - pathEnv: The value of the PATH environment variable
+//   - toolNames: The names of the symlinks managed in binDir
+func CheckPath(binDir, pathEnv string, toolNames []string) []Finding {
+	dirs := filepath.SplitList(pathEnv)
+	binIndex := pathIndex(binDir, dirs)
+	if binIndex < 0 {
+		return []Finding{newFinding(Error,
+			fmt.Sprintf("%s is not in your PATH", binDir),
+			"Tools activated with 'binarius use' can't be found by your shell",
+			fmt.Sprintf("Add 'export PATH=\"%s:$PATH\"' to your shell configuration (~/.bashrc or ~/.zshrc)", binDir),
+		)}
+	}
+
+	var findings []Finding
+	for _, name := range sortedCopy(toolNames) {
+		for _, dir := range dirs[:binIndex] {
+			if dir == "" {
+				continue
+			}
+			candidate := filepath.Join(dir, name)
+			if isExecutable(candidate) {
+				findings = append(findings, newFinding(Warning,
+					fmt.Sprintf("%s is shadowed by %s", name, candidate),
+					fmt.Sprintf("%s comes before %s in PATH, so your shell runs a version not managed by Binarius", dir, binDir),
+					fmt.Sprintf("Remove %s, or move %s to the front of PATH", candidate, binDir),
+				))
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// CheckSymlinks reports symlinks in binDir that point into toolsDir but whose target no longer exists.
+func CheckSymlinks(binDir, toolsDir string) []Finding {
+	entries, err := os.ReadDir(binDir)
+	if err != nil {
+		return nil // A missing bin directory is reported by CheckWritable
+	}
+
+	var findings []Finding
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+
+		link := filepath.Join(binDir, entry.Name())
+		target, err := os.Readlink(link)
+		if err != nil || !within(target, toolsDir) {
+			continue // Not one of ours
+		}
+
+		if _, err := os.Stat(link); err != nil {
+			findings = append(findings, newFinding(Error,
+				fmt.Sprintf("Dangling symlink: %s", link),
+				fmt.Sprintf("It points to %s, which no longer exists", target),
+				fmt.Sprintf("Run 'binarius use %s@<version>' to point it at an installed version, or remove it", entry.Name()),
+			))
+		}
+	}
+
+	return findings
+}
+
+// CheckRegistry reports registry entries whose binaries are missing.
+func CheckRegistry(registry *config.Registry) []Finding {
+	var findings []Finding
+	for _, toolName := range sortedCopy(registry.ListTools()) {
+		for _, version := range sortedCopy(registry.ListVersions(toolName)) {
+			tv := registry.GetVersion(toolName, version)
+			if _, err := os.Stat(tv.BinaryPath); err != nil {
+				findings = append(findings, newFinding(Error,
+					fmt.Sprintf("%s@%s is missing its binary", toolName, version),
+					fmt.Sprintf("installation.json lists %s, but it doesn't exist", tv.BinaryPath),
+					fmt.Sprintf("Reinstall with 'binarius uninstall %s@%s --force' followed by 'binarius install %s@%s'", toolName, version, toolName, version),
+				))
+			}
+		}
+	}
+
+	return findings
+}
+
+// CheckOrphans reports version directories in toolsDir that aren't recorded in the registry.
+// Hidden entries, such as in-progress staging directories, are ignored.
+func CheckOrphans(toolsDir string, registry *config.Registry) []Finding {
+	toolEntries, err := os.ReadDir(toolsDir)
+	if err != nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, toolEntry := range toolEntries {
+		if !toolEntry.IsDir() || strings.HasPrefix(toolEntry.Name(), ".") {
+			continue
+		}
+
+		versionEntries, err := os.ReadDir(filepath.Join(toolsDir, toolEntry.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, versionEntry := range versionEntries {
+			if !versionEntry.IsDir() || strings.HasPrefix(versionEntry.Name(), ".") {
+				continue
+			}
+
+			if registry.IsInstalled(toolEntry.Name(), versionEntry.Name()) {
+				continue
+			}
+
+			dir := filepath.Join(toolsDir, toolEntry.Name(), versionEntry.Name())
+			findings = append(findings, newFinding(Warning,
+				fmt.Sprintf("Orphan directory: %s", dir),
+				"It isn't recorded in installation.json, so Binarius can't use or uninstall it",
+				fmt.Sprintf("Remove it, or reinstall with 'binarius install %s@%s'", toolEntry.Name(), versionEntry.Name()),
+			))
+		}
+	}
+
+	return findings
+}
+
+// CheckWritable reports directories Binarius needs to write to but can't.
+func CheckWritable(dirs ...string) []Finding {
+	var findings []Finding
+	for _, dir := range dirs {
+		if !utils.IsWritable(dir) {
+			findings = append(findings, newFinding(Error,
+				fmt.Sprintf("%s is not writable", dir),
+				"Binarius can't install or activate tools without write access",
+				fmt.Sprintf("Fix the permissions or ownership of %s", dir),
+			))
+		}
+	}
+
+	return findings
+}
+
+// pathIndex returns the index of dir in dirs, or -1.
+func pathIndex(dir string, dirs []string) int {
+	want := canonical(dir)
+	for i, d := range dirs {
+		if d != "" && canonical(d) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// canonical returns a cleaned absolute path with symlinks resolved where possible.
+func canonical(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Clean(path)
+}
+
+// within reports whether path is inside dir.
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// isExecutable reports whether path is an executable regular file.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
+
+func sortedCopy(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,164 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nixknight/binarius/pkg/config"
+)
+
+// TestInPath verifies exact PATH entry matching.
+func TestInPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		pathEnv string
+		want    bool
+	}{
+		{name: "present", dir: "/home/u/.local/bin", pathEnv: "/usr/bin:/home/u/.local/bin", want: true},
+		{name: "trailing slash", dir: "/home/u/.local/bin", pathEnv: "/home/u/.local/bin/:/usr/bin", want: true},
+		{name: "substring only", dir: "/home/u/.local/bin", pathEnv: "/home/u/.local/bin2:/usr/bin", want: false},
+		{name: "absent", dir: "/home/u/.local/bin", pathEnv: "/usr/bin:/bin", want: false},
+		{name: "empty PATH", dir: "/home/u/.local/bin", pathEnv: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InPath(tt.dir, tt.pathEnv); got != tt.want {
+				t.Errorf("InPath(%q, %q) = %v, want %v", tt.dir, tt.pathEnv, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckPath verifies detection of a missing bin directory and shadowed tools.
+func TestCheckPath(t *testing.T) {
+	root := t.TempDir()
+	binDir := mkdir(t, root, "bin")
+	shadowDir := mkdir(t, root, "shadow")
+	laterDir := mkdir(t, root, "later")
+
+	writeExecutable(t, filepath.Join(shadowDir, "terraform"))
+	writeExecutable(t, filepath.Join(laterDir, "tofu"))
+
+	tests := []struct {
+		name        string
+		pathEnv     string
+		wantCount   int
+		wantContext string
+	}{
+		{
+			name:        "bin directory missing from PATH",
+			pathEnv:     shadowDir,
+			wantCount:   1,
+			wantContext: "is not in your PATH",
+		},
+		{
+			name:        "tool shadowed by earlier directory",
+			pathEnv:     strings.Join([]string{shadowDir, binDir, laterDir}, string(os.PathListSeparator)),
+			wantCount:   1,
+			wantContext: "terraform is shadowed",
+		},
+		{
+			name:      "bin directory first",
+			pathEnv:   strings.Join([]string{binDir, shadowDir, laterDir}, string(os.PathListSeparator)),
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := CheckPath(binDir, tt.pathEnv, []string{"terraform", "tofu"})
+			if len(findings) != tt.wantCount {
+				t.Fatalf("CheckPath() returned %d findings, want %d: %+v", len(findings), tt.wantCount, findings)
+			}
+			if tt.wantContext != "" && !strings.Contains(findings[0].Problem.Context, tt.wantContext) {
+				t.Errorf("CheckPath() context = %q, want it to contain %q", findings[0].Problem.Context, tt.wantContext)
+			}
+		})
+	}
+}
+
+// TestCheckSymlinks verifies that only dangling symlinks into the tools directory are reported.
+func TestCheckSymlinks(t *testing.T) {
+	root := t.TempDir()
+	binDir := mkdir(t, root, "bin")
+	toolsDir := mkdir(t, root, "tools")
+
+	installed := filepath.Join(toolsDir, "terraform", "v1.6.0", "terraform")
+	if err := os.MkdirAll(filepath.Dir(installed), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeExecutable(t, installed)
+
+	symlink(t, installed, filepath.Join(binDir, "terraform"))
+	symlink(t, filepath.Join(toolsDir, "tofu", "v1.6.0", "tofu"), filepath.Join(binDir, "tofu"))
+	symlink(t, filepath.Join(root, "elsewhere"), filepath.Join(binDir, "unrelated"))
+
+	findings := CheckSymlinks(binDir, toolsDir)
+	if len(findings) != 1 {
+		t.Fatalf("CheckSymlinks() returned %d findings, want 1: %+v", len(findings), findings)
+	}
+	if !strings.Contains(findings[0].Problem.Context, "tofu") || findings[0].Severity != Error {
+		t.Errorf("CheckSymlinks() finding = %+v, want an error for the tofu symlink", findings[0])
+	}
+}
+
+// TestCheckRegistryAndOrphans verifies detection of missing binaries and unregistered directories.
+func TestCheckRegistryAndOrphans(t *testing.T) {
+	toolsDir := t.TempDir()
+
+	present := filepath.Join(toolsDir, "terraform", "v1.6.0", "terraform")
+	if err := os.MkdirAll(filepath.Dir(present), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeExecutable(t, present)
+	mkdir(t, toolsDir, filepath.Join("terraform", "v1.5.0"))         // orphan
+	mkdir(t, toolsDir, filepath.Join("terraform", ".staging-x-123")) // ignored
+
+	registry := config.NewRegistry()
+	registry.AddVersion("terraform", "v1.6.0", config.ToolVersion{BinaryPath: present})
+	registry.AddVersion("tofu", "v1.6.0", config.ToolVersion{BinaryPath: filepath.Join(toolsDir, "tofu", "v1.6.0", "tofu")})
+
+	missing := CheckRegistry(registry)
+	if len(missing) != 1 || !strings.Contains(missing[0].Problem.Context, "tofu@v1.6.0") {
+		t.Errorf("CheckRegistry() = %+v, want one finding for tofu@v1.6.0", missing)
+	}
+
+	orphans := CheckOrphans(toolsDir, registry)
+	if len(orphans) != 1 || !strings.Contains(orphans[0].Problem.Context, "v1.5.0") {
+		t.Errorf("CheckOrphans() = %+v, want one finding for terraform v1.5.0", orphans)
+	}
+}
+
+// TestCheckWritable verifies writable directories produce no findings.
+func TestCheckWritable(t *testing.T) {
+	if findings := CheckWritable(t.TempDir()); len(findings) != 0 {
+		t.Errorf("CheckWritable() = %+v, want no findings", findings)
+	}
+}
+
+func mkdir(t *testing.T, parent, name string) string {
+	t.Helper()
+	dir := filepath.Join(parent, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	return dir
+}
+
+func writeExecutable(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func symlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("failed to create symlink %s: %v", link, err)
+	}
+}
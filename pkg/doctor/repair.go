@@ -0,0 +1,198 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/installer"
+	"github.com/nixknight/binarius/pkg/tools"
+)
+
+// Change describes a single change made to the registry by Repair.
+type Change struct {
+	Tool    string
+	Version string
+	Action  string
+}
+
+// String formats the change as "tool@version: action".
+func (c Change) String() string {
+	return fmt.Sprintf("%s@%s: %s", c.Tool, c.Version, c.Action)
+}
+
+// Repair reconciles the registry with the version directories in toolsDir.
+// The registry is modified in place:
+//   - Entries whose version directory is gone are removed
+//   - Entries whose binary is gone are marked partial
+//   - Version directories missing from the registry are added, with size and
+//     architecture derived from the files on disk
+//   - Missing metadata of existing entries is filled in; binaries that no
+//     longer match their recorded checksum are marked broken
+//
+// Binaries that match their recorded checksum are marked complete. Binaries
+// without a recorded checksum, such as those found on disk, can't be verified
+// and are left partial, unless trustExisting is set: then an executable
+// binary is hashed in place, its checksum recorded, and it is marked complete.
+// Hidden entries (such as staging directories) and directories of unknown
+// tools are left alone.
+func Repair(registry *config.Registry, toolsDir string, trustExisting bool) ([]Change, error) {
+	var changes []Change
+
+	// Reconcile existing entries with the filesystem
+	for _, toolName := range sortedCopy(registry.ListTools()) {
+		for _, version := range sortedCopy(registry.ListVersions(toolName)) {
+			versionDir := filepath.Join(toolsDir, toolName, version)
+			if _, err := os.Stat(versionDir); os.IsNotExist(err) {
+				registry.RemoveVersion(toolName, version)
+				changes = append(changes, Change{toolName, version, "removed from registry (files are gone)"})
+				continue
+			}
+
+			tool, err := tools.Get(toolName)
+			if err != nil {
+				continue
+			}
+
+			tv := registry.GetVersion(toolName, version)
+			updated, action, err := refreshEntry(tv, tool, versionDir, trustExisting)
+			if err != nil {
+				return changes, err
+			}
+			if action != "" {
+				registry.AddVersion(toolName, version, updated)
+				changes = append(changes, Change{toolName, version, action})
+			}
+		}
+	}
+
+	// Register version directories the registry doesn't know about
+	toolEntries, err := os.ReadDir(toolsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return changes, fmt.Errorf("failed to read tools directory %s: %w", toolsDir, err)
+	}
+
+	for _, toolEntry := range toolEntries {
+		toolName := toolEntry.Name()
+		if !toolEntry.IsDir() || strings.HasPrefix(toolName, ".") {
+			continue
+		}
+
+		tool, err := tools.Get(toolName)
+		if err != nil {
+			continue
+		}
+
+		versionEntries, err := os.ReadDir(filepath.Join(toolsDir, toolName))
+		if err != nil {
+			return changes, fmt.Errorf("failed to read %s: %w", filepath.Join(toolsDir, toolName), err)
+		}
+
+		for _, versionEntry := range versionEntries {
+			version := versionEntry.Name()
+			if !versionEntry.IsDir() || strings.HasPrefix(version, ".") || registry.IsInstalled(toolName, version) {
+				continue
+			}
+
+			// Only directories named like Binarius version directories (e.g. v1.6.0)
+			if normalized, err := utils.NormalizeVersion(version); err != nil || normalized != version {
+				continue
+			}
+
+			versionDir := filepath.Join(toolsDir, toolName, version)
+			tv := config.ToolVersion{ToolName: toolName, Version: version, Status: config.StatusPartial}
+			if info, err := os.Stat(versionDir); err == nil {
+				tv.InstalledAt = info.ModTime()
+			}
+
+			tv, _, err := refreshEntry(tv, tool, versionDir, trustExisting)
+			if err != nil {
+				return changes, err
+			}
+
+			registry.AddVersion(toolName, version, tv)
+			_, statErr := os.Stat(tv.BinaryPath)
+			switch {
+			case tv.Status == config.StatusComplete:
+				changes = append(changes, Change{toolName, version, "added to registry"})
+			case statErr != nil:
+				changes = append(changes, Change{toolName, version, "added to registry as partial (binary missing)"})
+			default:
+				changes = append(changes, Change{toolName, version, "added to registry as partial (binary can't be verified)"})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// refreshEntry derives missing metadata of an entry from its version directory.
+// Returns the updated entry and a description of what changed, or "" if nothing did.
+// An entry is only marked complete if its binary matches the recorded checksum,
+// or if trustExisting is set and there is no recorded checksum but the binary
+// is executable. Otherwise the status is left as it is.
+func refreshEntry(tv config.ToolVersion, tool tools.Tool, versionDir string, trustExisting bool) (config.ToolVersion, string, error) {
+	var actions []string
+
+	binaryPath := filepath.Join(versionDir, tool.GetBinaryName())
+	if tv.BinaryPath != binaryPath {
+		tv.BinaryPath = binaryPath
+		actions = append(actions, "binary path updated")
+	}
+
+	info, err := os.Stat(binaryPath)
+	if err != nil || !info.Mode().IsRegular() {
+		if tv.Status != config.StatusPartial {
+			tv.Status = config.StatusPartial
+			actions = append(actions, "marked partial (binary missing)")
+		}
+		return tv, strings.Join(actions, ", "), nil
+	}
+
+	verified := false
+	if tv.BinaryChecksum != "" {
+		err := installer.VerifyBinary(binaryPath, tv.BinaryChecksum)
+		if errors.Is(err, installer.ErrBinaryModified) {
+			if tv.Status != config.StatusBroken {
+				tv.Status = config.StatusBroken
+				actions = append(actions, "marked broken (binary modified)")
+			}
+			return tv, strings.Join(actions, ", "), nil
+		}
+		if err != nil {
+			return tv, "", err
+		}
+		verified = true
+	} else if trustExisting && info.Mode().Perm()&0111 != 0 {
+		checksum, err := installer.ComputeSHA256(binaryPath)
+		if err != nil {
+			return tv, "", err
+		}
+		tv.BinaryChecksum = checksum
+		actions = append(actions, "binary checksum recorded")
+		verified = true
+	}
+
+	if tv.SizeBytes != info.Size() {
+		tv.SizeBytes = info.Size()
+		actions = append(actions, "size updated")
+	}
+
+	if tv.Architecture == "" {
+		if platform, err := installer.BinaryPlatform(binaryPath); err == nil {
+			tv.Architecture = platform
+			actions = append(actions, "architecture detected")
+		}
+	}
+
+	if verified && tv.Status != config.StatusComplete {
+		tv.Status = config.StatusComplete
+		actions = append(actions, "marked complete")
+	}
+
+	return tv, strings.Join(actions, ", "), nil
+}
@@ -0,0 +1,166 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/installer"
+)
+
+// TestRepair verifies reconciliation of the registry with the tools directory.
+func TestRepair(t *testing.T) {
+	root := t.TempDir()
+	toolsDir := mkdir(t, root, "tools")
+	cacheDir := mkdir(t, root, "cache")
+
+	// v1.6.0: registered and intact
+	intact := filepath.Join(mkdir(t, toolsDir, "terraform/v1.6.0"), "terraform")
+	writeExecutable(t, intact)
+	intactChecksum, err := installer.ComputeSHA256(intact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// v1.5.0: on disk but not registered, with its archive still cached
+	unregistered := filepath.Join(mkdir(t, toolsDir, "terraform/v1.5.0"), "terraform")
+	writeExecutable(t, unregistered)
+	archive := filepath.Join(cacheDir, "terraform_1.5.0_"+runtime.GOOS+"_"+runtime.GOARCH+".zip")
+	if err := os.WriteFile(archive, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// v1.5.1: interrupted install whose binary matches the recorded checksum
+	finished := filepath.Join(mkdir(t, toolsDir, "terraform/v1.5.1"), "terraform")
+	writeExecutable(t, finished)
+	finishedChecksum, err := installer.ComputeSHA256(finished)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// v1.5.2: interrupted install without a recorded checksum
+	writeExecutable(t, filepath.Join(mkdir(t, toolsDir, "terraform/v1.5.2"), "terraform"))
+
+	// v1.4.0: directory without a binary
+	mkdir(t, toolsDir, "terraform/v1.4.0")
+
+	// v1.7.0: binary modified after install
+	modified := filepath.Join(mkdir(t, toolsDir, "terraform/v1.7.0"), "terraform")
+	writeExecutable(t, modified)
+
+	// Ignored: staging directory, unknown tool, non-version directory
+	writeExecutable(t, filepath.Join(mkdir(t, toolsDir, "terraform/.staging-v1.8.0-1"), "terraform"))
+	writeExecutable(t, filepath.Join(mkdir(t, toolsDir, "unknown/v1.0.0"), "unknown"))
+	mkdir(t, toolsDir, "terraform/notes")
+
+	registry := config.NewRegistry()
+	registry.AddVersion("terraform", "v1.6.0", config.ToolVersion{
+		ToolName: "terraform", Version: "v1.6.0", BinaryPath: intact, BinaryChecksum: intactChecksum,
+		SizeBytes: 10, Architecture: "linux/amd64", Checksum: "abc", Status: config.StatusComplete,
+	})
+	registry.AddVersion("terraform", "v1.7.0", config.ToolVersion{
+		ToolName: "terraform", Version: "v1.7.0", BinaryPath: modified, BinaryChecksum: "0000",
+		Status: config.StatusComplete,
+	})
+	registry.AddVersion("terraform", "v1.5.1", config.ToolVersion{
+		ToolName: "terraform", Version: "v1.5.1", BinaryPath: finished, BinaryChecksum: finishedChecksum,
+		Status: config.StatusPartial,
+	})
+	registry.AddVersion("terraform", "v1.5.2", config.ToolVersion{
+		ToolName: "terraform", Version: "v1.5.2", Status: config.StatusPartial,
+	})
+	registry.AddVersion("terraform", "v1.3.0", config.ToolVersion{
+		ToolName: "terraform", Version: "v1.3.0", BinaryPath: filepath.Join(toolsDir, "terraform/v1.3.0/terraform"),
+	})
+
+	changes, err := Repair(registry, toolsDir, false)
+	if err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+
+	if registry.IsInstalled("terraform", "v1.3.0") {
+		t.Error("Repair() should remove v1.3.0, whose files are gone")
+	}
+
+	if got := registry.GetVersion("terraform", "v1.6.0"); got.Status != config.StatusComplete || got.Checksum != "abc" {
+		t.Errorf("Repair() changed intact v1.6.0: %+v", got)
+	}
+
+	// Nothing vouches for the binary or the cached archive, so neither is trusted
+	added := registry.GetVersion("terraform", "v1.5.0")
+	if added.Status != config.StatusPartial || added.BinaryPath != unregistered || added.SizeBytes == 0 {
+		t.Errorf("Repair() v1.5.0 = %+v, want a partial entry derived from disk", added)
+	}
+	if added.BinaryChecksum != "" || added.Checksum != "" {
+		t.Errorf("Repair() v1.5.0 recorded unverified checksums: %+v", added)
+	}
+
+	if got := registry.GetVersion("terraform", "v1.5.1").Status; got != config.StatusComplete {
+		t.Errorf("Repair() v1.5.1 status = %q, want %q", got, config.StatusComplete)
+	}
+
+	if got := registry.GetVersion("terraform", "v1.5.2"); got.Status != config.StatusPartial || got.BinaryChecksum != "" {
+		t.Errorf("Repair() v1.5.2 = %+v, want a partial entry without a checksum", got)
+	}
+
+	if got := registry.GetVersion("terraform", "v1.4.0").Status; got != config.StatusPartial {
+		t.Errorf("Repair() v1.4.0 status = %q, want %q", got, config.StatusPartial)
+	}
+
+	if got := registry.GetVersion("terraform", "v1.7.0").Status; got != config.StatusBroken {
+		t.Errorf("Repair() v1.7.0 status = %q, want %q", got, config.StatusBroken)
+	}
+
+	if registry.IsInstalled("unknown", "v1.0.0") || registry.IsInstalled("terraform", "notes") || registry.IsInstalled("terraform", ".staging-v1.8.0-1") {
+		t.Error("Repair() registered an ignored directory")
+	}
+
+	// v1.3.0 removed, v1.4.0 and v1.5.0 added, v1.5.1 marked complete, v1.5.2
+	// given its binary path and size, v1.7.0 marked broken; v1.6.0 is untouched
+	if len(changes) != 6 {
+		t.Errorf("Repair() returned %d changes, want 6: %v", len(changes), changes)
+	}
+
+	// A second run finds nothing left to do
+	changes, err = Repair(registry, toolsDir, false)
+	if err != nil {
+		t.Fatalf("second Repair() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("second Repair() returned changes: %v", changes)
+	}
+}
+
+// TestRepairTrustExisting verifies that trusted binaries on disk are hashed
+// in place and marked complete, while non-executable files stay partial.
+func TestRepairTrustExisting(t *testing.T) {
+	toolsDir := mkdir(t, t.TempDir(), "tools")
+
+	binary := filepath.Join(mkdir(t, toolsDir, "terraform/v1.6.0"), "terraform")
+	writeExecutable(t, binary)
+	wantChecksum, err := installer.ComputeSHA256(binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notExecutable := filepath.Join(mkdir(t, toolsDir, "terraform/v1.5.0"), "terraform")
+	if err := os.WriteFile(notExecutable, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := config.NewRegistry()
+	if _, err := Repair(registry, toolsDir, true); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+
+	got := registry.GetVersion("terraform", "v1.6.0")
+	if got.Status != config.StatusComplete || got.BinaryChecksum != wantChecksum || got.SizeBytes == 0 {
+		t.Errorf("Repair() v1.6.0 = %+v, want a complete entry with checksum %q", got, wantChecksum)
+	}
+
+	if got := registry.GetVersion("terraform", "v1.5.0"); got.Status != config.StatusPartial || got.BinaryChecksum != "" {
+		t.Errorf("Repair() v1.5.0 = %+v, want a partial entry without a checksum", got)
+	}
+}
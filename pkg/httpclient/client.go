@@ -0,0 +1,135 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Options configures the shared HTTP transport used for all outgoing requests.
+type Options struct {
+	CABundle   string // Path to a PEM file with additional trusted CA certificates
+	ClientCert string // Path to a PEM client certificate for mirrors that require mTLS
+	ClientKey  string // Path to the PEM private key matching ClientCert
+}
+
+var (
+	mu        sync.RWMutex
+	transport = newTransport(nil)
+)
+
+// Configure rebuilds the shared transport from the given options.
+// Proxy settings are always taken from the HTTPS_PROXY, HTTP_PROXY, and NO_PROXY
+// environment variables. This function is safe for concurrent use.
+func Configure(opts Options) error {
+	tlsConfig, err := buildTLSConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	transport = newTransport(tlsConfig)
+
+	return nil
+}
+
+// New returns an HTTP client with the given timeout that uses the shared transport.
+// This function is safe for concurrent use.
+func New(timeout time.Duration) *http.Client {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     &loggingTransport{base: transport},
+		CheckRedirect: logRedirect,
+	}
+}
+
+// loggingTransport logs every request and its outcome at debug level.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	slog.Debug("HTTP request", "method", req.Method, "url", req.URL.Redacted())
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		slog.Debug("HTTP request failed", "url", req.URL.Redacted(), "error", err, "duration", time.Since(start))
+		return nil, err
+	}
+
+	slog.Debug("HTTP response", "url", req.URL.Redacted(), "status", resp.StatusCode,
+		"content_length", resp.ContentLength, "duration", time.Since(start))
+	return resp, nil
+}
+
+// logRedirect logs redirects and otherwise behaves like the default policy,
+// which stops after 10 redirects.
+func logRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	slog.Debug("HTTP redirect", "from", via[len(via)-1].URL.Redacted(), "to", req.URL.Redacted())
+	return nil
+}
+
+// newTransport creates a transport that honors proxy environment variables
+// and uses the provided TLS configuration (nil means system defaults).
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	t.TLSClientConfig = tlsConfig
+	return t
+}
+
+// buildTLSConfig creates a TLS configuration from the options.
+// Returns nil if no TLS customization is requested.
+func buildTLSConfig(opts Options) (*tls.Config, error) {
+	if opts.CABundle == "" && opts.ClientCert == "" && opts.ClientKey == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CABundle != "" {
+		pem, err := os.ReadFile(opts.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", opts.CABundle, err)
+		}
+
+		// Extend the system pool so public endpoints keep working
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA bundle %s", opts.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" || opts.ClientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be set together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeServerCA writes the TLS test server's certificate as a PEM bundle.
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+
+	bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundlePath, data, 0644); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+
+	return bundlePath
+}
+
+// TestConfigureCABundle verifies that a custom CA bundle is trusted by new clients.
+func TestConfigureCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	t.Cleanup(func() { _ = Configure(Options{}) })
+
+	// Default transport does not trust the test server
+	if err := Configure(Options{}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if resp, err := New(5 * time.Second).Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected TLS verification error without CA bundle, got nil")
+	}
+
+	// With the CA bundle the request succeeds
+	if err := Configure(Options{CABundle: writeServerCA(t, server)}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	resp, err := New(5 * time.Second).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() with CA bundle error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Get() status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+// TestConfigureErrors verifies invalid TLS options are rejected.
+func TestConfigureErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	invalidBundle := filepath.Join(tmpDir, "invalid.pem")
+	if err := os.WriteFile(invalidBundle, []byte("not a certificate"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	t.Cleanup(func() { _ = Configure(Options{}) })
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "missing CA bundle", opts: Options{CABundle: filepath.Join(tmpDir, "missing.pem")}},
+		{name: "CA bundle without certificates", opts: Options{CABundle: invalidBundle}},
+		{name: "client cert without key", opts: Options{ClientCert: invalidBundle}},
+		{name: "client key without cert", opts: Options{ClientKey: invalidBundle}},
+		{name: "invalid client key pair", opts: Options{ClientCert: invalidBundle, ClientKey: invalidBundle}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Configure(tt.opts); err == nil {
+				t.Errorf("Configure() expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestNewTimeout verifies the client timeout is applied.
+func TestNewTimeout(t *testing.T) {
+	client := New(42 * time.Second)
+	if client.Timeout != 42*time.Second {
+		t.Errorf("New() timeout = %v, want %v", client.Timeout, 42*time.Second)
+	}
+	if client.Transport == nil {
+		t.Error("New() should use the shared transport")
+	}
+}
+
+// TestNewLogsRequests verifies requests and redirects are logged at debug level.
+func TestNewLogsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	resp, err := New(5 * time.Second).Get(server.URL + "/old")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	for _, want := range []string{
+		`msg="HTTP request" method=GET url=` + server.URL + "/old",
+		`msg="HTTP redirect" from=` + server.URL + "/old to=" + server.URL + "/new",
+		`msg="HTTP response" url=` + server.URL + "/new status=200",
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs don't contain %q:\n%s", want, logs.String())
+		}
+	}
+}
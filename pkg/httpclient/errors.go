@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// StatusError reports an HTTP response with an unexpected status code.
+type StatusError struct {
+	StatusCode  int
+	RateLimited bool // 429, or a 403 from GitHub once the API rate limit is used up
+}
+
+// NewStatusError describes an unexpected response.
+func NewStatusError(resp *http.Response) *StatusError {
+	rateLimited := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0")
+	return &StatusError{StatusCode: resp.StatusCode, RateLimited: rateLimited}
+}
+
+func (e *StatusError) Error() string {
+	if e.RateLimited {
+		return fmt.Sprintf("HTTP %d: rate limit exceeded", e.StatusCode)
+	}
+	return fmt.Sprintf("HTTP %d", e.StatusCode)
+}
+
+// ErrorCode classifies a failed request for utils.UserError: rate limiting,
+// a missing file, or a network problem. Other errors are CodeGeneric.
+func ErrorCode(err error) utils.Code {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.RateLimited:
+			return utils.CodeRateLimited
+		case statusErr.StatusCode == http.StatusNotFound:
+			return utils.CodeNotFound
+		default:
+			return utils.CodeNetwork
+		}
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return utils.CodeNetwork
+	}
+
+	return utils.CodeOf(err)
+}
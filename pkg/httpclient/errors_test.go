@@ -0,0 +1,40 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+func TestErrorCode(t *testing.T) {
+	response := func(status int, header http.Header) *http.Response {
+		return &http.Response{StatusCode: status, Header: header}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want utils.Code
+	}{
+		{name: "too many requests", err: NewStatusError(response(http.StatusTooManyRequests, http.Header{})), want: utils.CodeRateLimited},
+		{name: "github rate limit", err: NewStatusError(response(http.StatusForbidden, http.Header{"X-Ratelimit-Remaining": {"0"}})), want: utils.CodeRateLimited},
+		{name: "forbidden", err: NewStatusError(response(http.StatusForbidden, http.Header{})), want: utils.CodeNetwork},
+		{name: "not found", err: fmt.Errorf("failed to fetch: %w", NewStatusError(response(http.StatusNotFound, http.Header{}))), want: utils.CodeNotFound},
+		{name: "server error", err: NewStatusError(response(http.StatusBadGateway, http.Header{})), want: utils.CodeNetwork},
+		{name: "connection failure", err: &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection refused")}, want: utils.CodeNetwork},
+		{name: "user error", err: utils.NewUserError("a", "b", "c").WithCode(utils.CodeChecksumMismatch), want: utils.CodeChecksumMismatch},
+		{name: "other error", err: errors.New("boom"), want: utils.CodeGeneric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Errorf("ErrorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
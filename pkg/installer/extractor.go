@@ -3,9 +3,11 @@ package installer
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +15,87 @@ import (
 	"github.com/nixknight/binarius/internal/utils"
 )
 
+// DetectArchiveFormat identifies a file by its magic bytes and returns "zip",
+// "tar.gz", or "binary" for anything that isn't a supported archive.
+func DetectArchiveFormat(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	header := make([]byte, 4)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return "zip", nil
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return "tar.gz", nil
+	default:
+		return "binary", nil
+	}
+}
+
+// Unpack places the contents of an archive in destDir according to its
+// format: "zip" and "tar.gz" archives are extracted, and a "binary" is copied
+// to destDir/binaryName and made executable.
+func Unpack(archivePath, format, destDir, binaryName string) error {
+	switch format {
+	case "zip":
+		return ExtractZip(archivePath, destDir)
+	case "tar.gz":
+		return ExtractTarGz(archivePath, destDir)
+	case "binary":
+		if err := copyBinary(archivePath, filepath.Join(destDir, binaryName)); err != nil {
+			return utils.NewUserError(
+				"Failed to copy binary",
+				err.Error(),
+				"Ensure you have write permissions for ~/.binarius",
+			).WithCode(utils.CodeFilesystem)
+		}
+		return nil
+	default:
+		return utils.NewUserError(
+			"Unsupported archive format",
+			fmt.Sprintf("Archive format '%s' is not supported", format),
+			"This is a bug. Please report it to the maintainer.",
+		)
+	}
+}
+
+// copyBinary copies src to dst with executable permissions.
+func copyBinary(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = srcFile.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		_ = dstFile.Close()
+		return err
+	}
+	if err := dstFile.Close(); err != nil {
+		return err
+	}
+
+	// OpenFile's mode is subject to the umask
+	return os.Chmod(dst, 0755)
+}
+
 // ExtractZip extracts a ZIP archive to the specified destination directory.
 // Sets executable permissions (0755) on extracted files.
 // Prevents path traversal attacks by validating all extraction paths.
@@ -32,13 +115,15 @@ func ExtractZip(zipPath, destDir string) error {
 	}
 	defer func() { _ = reader.Close() }()
 
+	slog.Debug("Extracting ZIP archive", "archive", zipPath, "dest", destDir, "entries", len(reader.File))
+
 	// Create destination directory if it doesn't exist
 	if err := os.MkdirAll(destDir, 0755); err != nil {
 		return utils.NewUserError(
 			fmt.Sprintf("Failed to create destination directory: %s", destDir),
 			err.Error(),
 			"Ensure you have write permissions for the directory",
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
 
 	// Extract each file in the archive
@@ -94,6 +179,7 @@ func extractZipFile(file *zip.File, destDir string) error {
 		return fmt.Errorf("failed to close extracted file %s: %w", targetPath, err)
 	}
 
+	slog.Debug("Extracted file", "path", targetPath)
 	return nil
 }
 
@@ -130,13 +216,15 @@ func ExtractTarGz(tarGzPath, destDir string) error {
 	// Create tar reader
 	tarReader := tar.NewReader(gzipReader)
 
+	slog.Debug("Extracting tar.gz archive", "archive", tarGzPath, "dest", destDir)
+
 	// Create destination directory if it doesn't exist
 	if err := os.MkdirAll(destDir, 0755); err != nil {
 		return utils.NewUserError(
 			fmt.Sprintf("Failed to create destination directory: %s", destDir),
 			err.Error(),
 			"Ensure you have write permissions for the directory",
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
 
 	// Extract each file in the archive
@@ -194,10 +282,11 @@ func extractTarFile(tarReader *tar.Reader, header *tar.Header, destDir string) e
 		if err := destFile.Close(); err != nil {
 			return fmt.Errorf("failed to close extracted file %s: %w", targetPath, err)
 		}
+		slog.Debug("Extracted file", "path", targetPath)
 
 	default:
 		// Skip other file types (symlinks, devices, etc.)
-		// Log or ignore based on requirements
+		slog.Debug("Skipped archive entry", "name", header.Name, "type", string(header.Typeflag))
 	}
 
 	return nil
@@ -397,3 +397,95 @@ func TestValidateExtractPath(t *testing.T) {
 		})
 	}
 }
+
+// TestDetectArchiveFormat verifies that archives are recognized by content, not name.
+func TestDetectArchiveFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	zipPath := filepath.Join(tmpDir, "terraform.bin")
+	createTestZip(t, zipPath, map[string][]byte{"terraform": []byte("binary")})
+
+	tarGzPath := filepath.Join(tmpDir, "tofu.zip")
+	createTestTarGz(t, tarGzPath, map[string][]byte{"tofu": []byte("binary")})
+
+	binaryPath := filepath.Join(tmpDir, "terraform.zip")
+	if err := os.WriteFile(binaryPath, []byte("\x7fELF\x02\x01\x01"), 0755); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+
+	emptyPath := filepath.Join(tmpDir, "empty")
+	if err := os.WriteFile(emptyPath, nil, 0644); err != nil {
+		t.Fatalf("failed to write empty file: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{zipPath, "zip"},
+		{tarGzPath, "tar.gz"},
+		{binaryPath, "binary"},
+		{emptyPath, "binary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			got, err := DetectArchiveFormat(tt.path)
+			if err != nil {
+				t.Fatalf("DetectArchiveFormat() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectArchiveFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DetectArchiveFormat(filepath.Join(tmpDir, "missing")); err == nil {
+		t.Error("DetectArchiveFormat() of a missing file should fail")
+	}
+}
+
+// TestUnpackBinary verifies that a bare binary is copied into place as the tool's binary.
+func TestUnpackBinary(t *testing.T) {
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "terraform_1.6.0")
+	content := []byte("\x7fELF fake terraform binary")
+	if err := os.WriteFile(binaryPath, content, 0644); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+
+	format, err := DetectArchiveFormat(binaryPath)
+	if err != nil {
+		t.Fatalf("DetectArchiveFormat() error = %v", err)
+	}
+
+	destDir := filepath.Join(tmpDir, "staging")
+	if err := Unpack(binaryPath, format, destDir, "terraform"); err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+
+	installed := filepath.Join(destDir, "terraform")
+	got, err := os.ReadFile(installed)
+	if err != nil {
+		t.Fatalf("binary not installed: %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("installed binary = %q, want %q", got, content)
+	}
+
+	info, err := os.Stat(installed)
+	if err != nil {
+		t.Fatalf("failed to stat binary: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("binary permissions = %o, want 755", info.Mode().Perm())
+	}
+}
+
+// TestUnpackUnsupportedFormat verifies that unknown formats are rejected.
+func TestUnpackUnsupportedFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := Unpack(filepath.Join(tmpDir, "archive"), "rar", tmpDir, "terraform"); err == nil {
+		t.Error("Unpack() with an unsupported format should fail")
+	}
+}
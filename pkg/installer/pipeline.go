@@ -0,0 +1,122 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/nixknight/binarius/internal/utils"
+	"github.com/nixknight/binarius/pkg/config"
+)
+
+// ErrUnavailable is returned by a Verifier when the material it needs
+// (a checksum file, signature, or certificate) isn't available for a release.
+var ErrUnavailable = errors.New("verification material not available")
+
+// Artifact describes a downloaded release file and the checksum file that covers it.
+type Artifact struct {
+	Path         string   // The archive or binary to verify
+	ChecksumFile string   // The checksum file listing Path (empty if none)
+	Names        []string // File names under which Path may appear in ChecksumFile, tried in order
+}
+
+// Verifier is a single verification step.
+type Verifier interface {
+	// Name returns the identifier recorded in the registry, e.g. "sha256" or "gpg".
+	Name() string
+
+	// Signature reports whether the verifier checks the authenticity of the
+	// checksum file rather than the artifact itself. Signatures run first.
+	Signature() bool
+
+	// Required reports whether the verifier must run even under config.PolicyDefault.
+	Required() bool
+
+	// Verify checks the artifact and returns a short description of what was verified.
+	// It returns an error wrapping ErrUnavailable if its material doesn't exist.
+	Verify(artifact *Artifact) (string, error)
+}
+
+// Pipeline runs a set of verifiers over an artifact according to a policy.
+type Pipeline struct {
+	Verifiers []Verifier
+	Policy    config.Policy
+
+	// OnResult is called after each verifier; err is nil on success and wraps
+	// ErrUnavailable when the verifier was skipped. May be nil.
+	OnResult func(name, detail string, err error)
+}
+
+// Run verifies the artifact. Signature verifiers run before checksum verifiers
+// so no checksum is trusted before the checksum file is authenticated.
+// Returns the names of the verifiers that passed.
+func (p *Pipeline) Run(artifact *Artifact) ([]string, error) {
+	if p.Policy == config.PolicyNone {
+		return nil, nil
+	}
+
+	var ordered []Verifier
+	for _, signature := range []bool{true, false} {
+		for _, v := range p.Verifiers {
+			if v.Signature() == signature {
+				ordered = append(ordered, v)
+			}
+		}
+	}
+
+	var passed []string
+	for _, v := range ordered {
+		if v.Signature() && p.Policy == config.PolicyChecksum {
+			continue
+		}
+
+		detail, err := v.Verify(artifact)
+		if err != nil {
+			slog.Debug("Verifier failed", "verifier", v.Name(), "artifact", artifact.Path, "error", err)
+		} else {
+			slog.Debug("Verifier passed", "verifier", v.Name(), "artifact", artifact.Path, "detail", detail)
+		}
+		if errors.Is(err, ErrUnavailable) && p.Policy != config.PolicyStrict && !v.Required() {
+			p.report(v.Name(), "", err)
+			continue
+		}
+		if err != nil {
+			if errors.Is(err, ErrUnavailable) {
+				return passed, utils.NewUserError(
+					fmt.Sprintf("Required %s verification could not run", v.Name()),
+					err.Error(),
+					"Ensure the release (or your mirror) provides checksum and signature files, or relax 'verification' for this tool in config.yaml",
+				).WithCode(utils.CodeVerificationUnavailable)
+			}
+			return passed, err
+		}
+
+		p.report(v.Name(), detail, nil)
+		passed = append(passed, v.Name())
+	}
+
+	return passed, nil
+}
+
+func (p *Pipeline) report(name, detail string, err error) {
+	if p.OnResult != nil {
+		p.OnResult(name, detail, err)
+	}
+}
+
+// checksumFileAvailable returns an ErrUnavailable error if the artifact has no checksum file.
+func checksumFileAvailable(artifact *Artifact) error {
+	if artifact.ChecksumFile == "" {
+		return fmt.Errorf("%w: no checksum file", ErrUnavailable)
+	}
+	return nil
+}
+
+// materialAvailable returns an ErrUnavailable error if path doesn't exist.
+func materialAvailable(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s not found", ErrUnavailable, path)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nixknight/binarius/pkg/config"
+)
+
+// fakeVerifier is a Verifier with a canned result.
+type fakeVerifier struct {
+	name      string
+	signature bool
+	required  bool
+	err       error
+	calls     *[]string
+}
+
+func (f *fakeVerifier) Name() string    { return f.name }
+func (f *fakeVerifier) Signature() bool { return f.signature }
+func (f *fakeVerifier) Required() bool  { return f.required }
+
+func (f *fakeVerifier) Verify(artifact *Artifact) (string, error) {
+	*f.calls = append(*f.calls, f.name)
+	return "ok", f.err
+}
+
+// TestPipelineRun verifies ordering and policy handling of the verification pipeline.
+func TestPipelineRun(t *testing.T) {
+	unavailable := fmt.Errorf("%w: no signature", ErrUnavailable)
+	mismatch := errors.New("checksum mismatch")
+
+	tests := []struct {
+		name       string
+		policy     config.Policy
+		sigErr     error
+		sigReq     bool
+		sumErr     error
+		wantCalls  []string
+		wantPassed []string
+		wantErr    bool
+	}{
+		{
+			name:       "signatures run before checksums",
+			wantCalls:  []string{"gpg", "sha256"},
+			wantPassed: []string{"gpg", "sha256"},
+		},
+		{
+			name:       "optional signature skipped when unavailable",
+			sigErr:     unavailable,
+			wantCalls:  []string{"gpg", "sha256"},
+			wantPassed: []string{"sha256"},
+		},
+		{
+			name:      "required signature fails when unavailable",
+			sigErr:    unavailable,
+			sigReq:    true,
+			wantCalls: []string{"gpg"},
+			wantErr:   true,
+		},
+		{
+			name:      "strict fails when any material is unavailable",
+			policy:    config.PolicyStrict,
+			sigErr:    unavailable,
+			wantCalls: []string{"gpg"},
+			wantErr:   true,
+		},
+		{
+			name:      "failed signature stops the pipeline",
+			sigErr:    errors.New("bad signature"),
+			wantCalls: []string{"gpg"},
+			wantErr:   true,
+		},
+		{
+			name:       "checksum policy skips signatures",
+			policy:     config.PolicyChecksum,
+			sigErr:     errors.New("bad signature"),
+			wantCalls:  []string{"sha256"},
+			wantPassed: []string{"sha256"},
+		},
+		{
+			name:      "checksum mismatch fails",
+			policy:    config.PolicyChecksum,
+			sumErr:    mismatch,
+			wantCalls: []string{"sha256"},
+			wantErr:   true,
+		},
+		{
+			name:   "none skips everything",
+			policy: config.PolicyNone,
+			sigErr: errors.New("bad signature"),
+			sumErr: mismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			pipeline := Pipeline{
+				Verifiers: []Verifier{
+					&fakeVerifier{name: "sha256", err: tt.sumErr, calls: &calls},
+					&fakeVerifier{name: "gpg", signature: true, required: tt.sigReq, err: tt.sigErr, calls: &calls},
+				},
+				Policy: tt.policy,
+			}
+
+			passed, err := pipeline.Run(&Artifact{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("Run() called %v, want %v", calls, tt.wantCalls)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(passed, tt.wantPassed) {
+				t.Errorf("Run() passed = %v, want %v", passed, tt.wantPassed)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package installer
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"errors"
+	"fmt"
+)
+
+// BinaryPlatform inspects an executable's header and returns its platform
+// in "os/arch" form (e.g. "linux/amd64"), matching ToolVersion.Architecture.
+func BinaryPlatform(path string) (string, error) {
+	if f, err := elf.Open(path); err == nil {
+		defer func() { _ = f.Close() }()
+		arch, ok := elfArchs[f.Machine]
+		if !ok {
+			return "", fmt.Errorf("unsupported ELF machine %s in %s", f.Machine, path)
+		}
+		return "linux/" + arch, nil
+	}
+
+	if f, err := macho.Open(path); err == nil {
+		defer func() { _ = f.Close() }()
+		arch, ok := machoArchs[f.Cpu]
+		if !ok {
+			return "", fmt.Errorf("unsupported Mach-O CPU %s in %s", f.Cpu, path)
+		}
+		return "darwin/" + arch, nil
+	}
+
+	if f, err := pe.Open(path); err == nil {
+		defer func() { _ = f.Close() }()
+		arch, ok := peArchs[f.Machine]
+		if !ok {
+			return "", fmt.Errorf("unsupported PE machine %#x in %s", f.Machine, path)
+		}
+		return "windows/" + arch, nil
+	}
+
+	return "", errors.New("not a recognized executable: " + path)
+}
+
+var elfArchs = map[elf.Machine]string{
+	elf.EM_X86_64:  "amd64",
+	elf.EM_AARCH64: "arm64",
+	elf.EM_386:     "386",
+	elf.EM_ARM:     "arm",
+}
+
+var machoArchs = map[macho.Cpu]string{
+	macho.CpuAmd64: "amd64",
+	macho.CpuArm64: "arm64",
+}
+
+var peArchs = map[uint16]string{
+	pe.IMAGE_FILE_MACHINE_AMD64: "amd64",
+	pe.IMAGE_FILE_MACHINE_ARM64: "arm64",
+	pe.IMAGE_FILE_MACHINE_I386:  "386",
+}
@@ -0,0 +1,35 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestBinaryPlatform verifies platform detection from executable headers.
+func TestBinaryPlatform(t *testing.T) {
+	// The test binary itself is a native executable
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable() error = %v", err)
+	}
+
+	got, err := BinaryPlatform(self)
+	if err != nil {
+		t.Fatalf("BinaryPlatform() error = %v", err)
+	}
+
+	want := runtime.GOOS + "/" + runtime.GOARCH
+	if got != want {
+		t.Errorf("BinaryPlatform() = %q, want %q", got, want)
+	}
+
+	script := filepath.Join(t.TempDir(), "script")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := BinaryPlatform(script); err == nil {
+		t.Error("BinaryPlatform() for a shell script expected error, got nil")
+	}
+}
@@ -0,0 +1,225 @@
+package installer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nixknight/binarius/pkg/httpclient"
+)
+
+// maxRekorEntries is how many transparency log entries for the same artifact
+// digest are fetched while looking for the one matching a signature.
+const maxRekorEntries = 10
+
+// errNoRekorEntry is returned by lookupRekorEntry when the log has no entry
+// for a signature, which means it was never logged.
+var errNoRekorEntry = errors.New("signature is not in the transparency log")
+
+// rekorEntry is a Rekor transparency log entry with the signed entry
+// timestamp (SET) the log issued for it. The SET is the log's promise that
+// the entry was recorded at IntegratedTime.
+type rekorEntry struct {
+	Body           string // Base64-encoded canonical entry body
+	IntegratedTime int64  // When the log recorded the entry, in Unix seconds
+	LogIndex       int64
+	LogID          string // Hex-encoded SHA256 of the log's DER public key
+	SET            []byte // The log's signature over the fields above
+}
+
+// verifySET checks that a trusted log signed the entry and returns the time
+// the entry was recorded.
+func (e *rekorEntry) verifySET(rekorKeys []byte) (time.Time, error) {
+	keys, err := parseRekorKeys(rekorKeys)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log keys: %w", err)
+	}
+
+	key, ok := keys[e.LogID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("entry was recorded by an untrusted transparency log %s", e.LogID)
+	}
+
+	// The SET signs the canonical JSON of these fields, with keys in sorted order
+	payload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{e.Body, e.IntegratedTime, e.LogID, e.LogIndex})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	digest := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(key, digest[:], e.SET) {
+		return time.Time{}, errors.New("signed entry timestamp does not match the entry")
+	}
+
+	return time.Unix(e.IntegratedTime, 0), nil
+}
+
+// checkBody ensures the entry records this signature, by this certificate,
+// over data with this SHA256 digest.
+func (e *rekorEntry) checkBody(digest [32]byte, sig []byte, leaf *x509.Certificate) error {
+	body, err := base64.StdEncoding.DecodeString(e.Body)
+	if err != nil {
+		return fmt.Errorf("invalid entry body: %w", err)
+	}
+
+	var entry struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Data struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content   string `json:"content"`
+				PublicKey struct {
+					Content string `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return fmt.Errorf("invalid entry body: %w", err)
+	}
+
+	if entry.Kind != "hashedrekord" {
+		return fmt.Errorf("unsupported transparency log entry kind %q", entry.Kind)
+	}
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || !strings.EqualFold(entry.Spec.Data.Hash.Value, hex.EncodeToString(digest[:])) {
+		return errors.New("transparency log entry is for different data")
+	}
+
+	logged, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.Content)
+	if err != nil || !bytes.Equal(logged, sig) {
+		return errors.New("transparency log entry is for a different signature")
+	}
+
+	certPEM, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.PublicKey.Content)
+	if err != nil {
+		return fmt.Errorf("invalid certificate in transparency log entry: %w", err)
+	}
+	certs, err := parseCertificates(certPEM)
+	if err != nil || !certs[0].Equal(leaf) {
+		return errors.New("transparency log entry is for a different certificate")
+	}
+
+	return nil
+}
+
+// parseRekorKeys parses a PEM bundle of ECDSA transparency log public keys,
+// keyed by log ID.
+func parseRekorKeys(data []byte) (map[string]*ecdsa.PublicKey, error) {
+	keys := make(map[string]*ecdsa.PublicKey)
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported public key type %T", pub)
+		}
+
+		id := sha256.Sum256(block.Bytes)
+		keys[hex.EncodeToString(id[:])] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no public keys found")
+	}
+
+	return keys, nil
+}
+
+// lookupRekorEntry searches the Rekor log at rekorURL for the entry recording
+// sig by leaf over data with the given digest.
+func lookupRekorEntry(rekorURL string, digest [32]byte, sig []byte, leaf *x509.Certificate) (*rekorEntry, error) {
+	client := httpclient.New(30 * time.Second)
+	rekorURL = strings.TrimSuffix(rekorURL, "/")
+
+	query, err := json.Marshal(map[string]string{"hash": "sha256:" + hex.EncodeToString(digest[:])})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Post(rekorURL+"/api/v1/index/retrieve", "application/json", bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	var uuids []string
+	if err := decodeRekorResponse(resp, &uuids); err != nil {
+		return nil, err
+	}
+
+	for i, uuid := range uuids {
+		if i == maxRekorEntries {
+			break
+		}
+
+		resp, err := client.Get(rekorURL + "/api/v1/log/entries/" + uuid)
+		if err != nil {
+			return nil, err
+		}
+		var entries map[string]struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogID          string `json:"logID"`
+			LogIndex       int64  `json:"logIndex"`
+			Verification   struct {
+				SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+			} `json:"verification"`
+		}
+		if err := decodeRekorResponse(resp, &entries); err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			set, err := base64.StdEncoding.DecodeString(e.Verification.SignedEntryTimestamp)
+			if err != nil {
+				continue
+			}
+			entry := &rekorEntry{Body: e.Body, IntegratedTime: e.IntegratedTime, LogIndex: e.LogIndex, LogID: e.LogID, SET: set}
+			if entry.checkBody(digest, sig, leaf) == nil {
+				return entry, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w at %s", errNoRekorEntry, rekorURL)
+}
+
+// decodeRekorResponse decodes a JSON response from the Rekor API into v.
+func decodeRekorResponse(resp *http.Response, v interface{}) error {
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return httpclient.NewStatusError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", resp.Request.URL, err)
+	}
+	return nil
+}
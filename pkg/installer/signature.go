@@ -0,0 +1,141 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// VerifyGPGSignature verifies a detached OpenPGP signature over a file
+// (typically a SHA256SUMS file) against a set of trusted public keys.
+// The signature may be binary or ASCII-armored.
+//
+// The signing key must have been valid (not expired) when the signature was
+// created, so releases signed before a key rotation keep verifying.
+//
+// Parameters:
+//   - dataPath: Path to the signed file
+//   - signaturePath: Path to the detached signature
+//   - armoredKeys: ASCII-armored public key blocks to trust
+//
+// Returns the fingerprint of the key that made the signature.
+func VerifyGPGSignature(dataPath, signaturePath string, armoredKeys ...[]byte) (string, error) {
+	keyring, err := readKeyring(armoredKeys)
+	if err != nil {
+		return "", err
+	}
+
+	sigData, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return "", utils.NewUserError(
+			fmt.Sprintf("Failed to read signature file: %s", signaturePath),
+			err.Error(),
+			"Ensure the signature file exists and is readable",
+		).WithCode(utils.CodeFilesystem)
+	}
+
+	sigData, err = dearmorSignature(sigData)
+	if err != nil {
+		return "", signatureError(dataPath, err)
+	}
+
+	// Evaluate key validity at signing time rather than now
+	created, err := signatureCreationTime(sigData)
+	if err != nil {
+		return "", signatureError(dataPath, err)
+	}
+
+	data, err := os.Open(dataPath)
+	if err != nil {
+		return "", utils.NewUserError(
+			fmt.Sprintf("Failed to open signed file: %s", dataPath),
+			err.Error(),
+			"Ensure the file exists and is readable",
+		).WithCode(utils.CodeFilesystem)
+	}
+	defer func() { _ = data.Close() }()
+
+	cfg := &packet.Config{Time: func() time.Time { return created }}
+	signer, err := openpgp.CheckDetachedSignature(keyring, data, bytes.NewReader(sigData), cfg)
+	if err != nil {
+		return "", signatureError(dataPath, err)
+	}
+
+	return fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint), nil
+}
+
+// readKeyring parses ASCII-armored public keys into a single keyring.
+func readKeyring(armoredKeys [][]byte) (openpgp.EntityList, error) {
+	var keyring openpgp.EntityList
+	for _, key := range armoredKeys {
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+		if err != nil {
+			return nil, utils.NewUserError(
+				"Failed to read trusted signing keys",
+				err.Error(),
+				"Ensure configured key files contain ASCII-armored OpenPGP public keys",
+			).WithCode(utils.CodeConfig)
+		}
+		keyring = append(keyring, entities...)
+	}
+
+	if len(keyring) == 0 {
+		return nil, utils.NewUserError(
+			"No trusted signing keys available",
+			"Signature verification requires at least one public key",
+			"This is a bug. Please report it to the maintainer.",
+		)
+	}
+
+	return keyring, nil
+}
+
+// dearmorSignature returns the binary form of a signature that may be ASCII-armored.
+func dearmorSignature(sigData []byte) ([]byte, error) {
+	if !strings.HasPrefix(strings.TrimSpace(string(sigData)), "-----BEGIN PGP SIGNATURE-----") {
+		return sigData, nil
+	}
+
+	block, err := armor.Decode(bytes.NewReader(sigData))
+	if err != nil {
+		return nil, fmt.Errorf("invalid armored signature: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(block.Body); err != nil {
+		return nil, fmt.Errorf("invalid armored signature: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// signatureCreationTime returns the creation time of a binary signature packet.
+func signatureCreationTime(sigData []byte) (time.Time, error) {
+	p, err := packet.Read(bytes.NewReader(sigData))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid signature: not a signature packet")
+	}
+
+	return sig.CreationTime, nil
+}
+
+// signatureError wraps a verification failure in a user-facing error.
+func signatureError(dataPath string, err error) error {
+	return utils.NewUserError(
+		"Signature verification failed",
+		fmt.Sprintf("Could not verify signature of %s: %v", dataPath, err),
+		"The checksums may have been tampered with. If the vendor rotated its signing key, add the new key under 'gpg.key_files' in config.yaml.",
+	).WithCode(utils.CodeSignatureInvalid)
+}
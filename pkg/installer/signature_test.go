@@ -0,0 +1,137 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// newTestKey creates a signing key valid for lifetime from created, and its armored public key.
+func newTestKey(t *testing.T, created time.Time, lifetime time.Duration) (*openpgp.Entity, []byte) {
+	t.Helper()
+
+	cfg := &packet.Config{
+		Algorithm:       packet.PubKeyAlgoEdDSA,
+		Time:            func() time.Time { return created },
+		KeyLifetimeSecs: uint32(lifetime.Seconds()),
+	}
+	entity, err := openpgp.NewEntity("Test Release", "", "release@example.com", cfg)
+	if err != nil {
+		t.Fatalf("failed to create test key: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("failed to armor key: %v", err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("failed to serialize key: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close armor writer: %v", err)
+	}
+
+	return entity, buf.Bytes()
+}
+
+// signFile writes a detached signature of dataPath made at signedAt.
+func signFile(t *testing.T, entity *openpgp.Entity, dataPath string, signedAt time.Time, armored bool) string {
+	t.Helper()
+
+	data, err := os.Open(dataPath)
+	if err != nil {
+		t.Fatalf("failed to open data: %v", err)
+	}
+	defer func() { _ = data.Close() }()
+
+	var buf bytes.Buffer
+	cfg := &packet.Config{Time: func() time.Time { return signedAt }}
+	if armored {
+		err = openpgp.ArmoredDetachSign(&buf, entity, data, cfg)
+	} else {
+		err = openpgp.DetachSign(&buf, entity, data, cfg)
+	}
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	sigPath := dataPath + ".sig"
+	if err := os.WriteFile(sigPath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write signature: %v", err)
+	}
+
+	return sigPath
+}
+
+// TestVerifyGPGSignature verifies detached signature checks.
+func TestVerifyGPGSignature(t *testing.T) {
+	now := time.Now()
+	entity, publicKey := newTestKey(t, now.Add(-time.Hour), 0)
+	_, otherKey := newTestKey(t, now.Add(-time.Hour), 0)
+
+	tests := []struct {
+		name    string
+		armored bool
+		tamper  bool
+		keys    [][]byte
+		wantErr bool
+	}{
+		{name: "valid binary signature", keys: [][]byte{publicKey}},
+		{name: "valid armored signature", armored: true, keys: [][]byte{publicKey}},
+		{name: "trusted key among several", keys: [][]byte{otherKey, publicKey}},
+		{name: "tampered data", tamper: true, keys: [][]byte{publicKey}, wantErr: true},
+		{name: "unknown signing key", keys: [][]byte{otherKey}, wantErr: true},
+		{name: "no trusted keys", keys: nil, wantErr: true},
+		{name: "invalid key material", keys: [][]byte{[]byte("not a key")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataPath := filepath.Join(t.TempDir(), "SHA256SUMS")
+			if err := os.WriteFile(dataPath, []byte("abc123  terraform_1.6.0_linux_amd64.zip\n"), 0644); err != nil {
+				t.Fatalf("failed to write data: %v", err)
+			}
+			sigPath := signFile(t, entity, dataPath, now.Add(-time.Minute), tt.armored)
+
+			if tt.tamper {
+				if err := os.WriteFile(dataPath, []byte("evil  terraform_1.6.0_linux_amd64.zip\n"), 0644); err != nil {
+					t.Fatalf("failed to tamper data: %v", err)
+				}
+			}
+
+			fingerprint, err := VerifyGPGSignature(dataPath, sigPath, tt.keys...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyGPGSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && fingerprint != fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint) {
+				t.Errorf("VerifyGPGSignature() fingerprint = %s, want %X", fingerprint, entity.PrimaryKey.Fingerprint)
+			}
+		})
+	}
+}
+
+// TestVerifyGPGSignatureKeyExpiry verifies that key validity is evaluated at signing time.
+func TestVerifyGPGSignatureKeyExpiry(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	entity, publicKey := newTestKey(t, created, 24*time.Hour) // expired a day ago
+
+	dataPath := filepath.Join(t.TempDir(), "SHA256SUMS")
+	if err := os.WriteFile(dataPath, []byte("abc123  tool.zip\n"), 0644); err != nil {
+		t.Fatalf("failed to write data: %v", err)
+	}
+
+	// Signed while the key was valid: still verifies after expiry
+	sigPath := signFile(t, entity, dataPath, created.Add(time.Hour), false)
+	if _, err := VerifyGPGSignature(dataPath, sigPath, publicKey); err != nil {
+		t.Errorf("VerifyGPGSignature() for signature made before expiry error = %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package installer
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StagingPrefix is the name prefix of staging directories created next to
+// version directories. Directories with this prefix are never valid installs.
+const StagingPrefix = ".staging-"
+
+// Staging is a temporary directory in which an installation is assembled
+// before being atomically renamed into its final location.
+// All methods are safe for concurrent use, so Cleanup may be called from a signal handler.
+type Staging struct {
+	Dir string // Temporary directory to extract files into
+
+	finalDir  string
+	mu        sync.Mutex
+	committed bool
+	cleaned   bool
+}
+
+// NewStaging creates a staging directory for finalDir.
+// The staging directory is created in the same parent directory as finalDir
+// so that the final rename stays on the same filesystem and is atomic.
+//
+// Parameters:
+//   - finalDir: The directory the installation should end up in
+func NewStaging(finalDir string) (*Staging, error) {
+	parent := filepath.Dir(finalDir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", parent, err)
+	}
+
+	dir, err := os.MkdirTemp(parent, StagingPrefix+filepath.Base(finalDir)+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory in %s: %w", parent, err)
+	}
+
+	// MkdirTemp uses 0700; installed tools should be readable like other version directories
+	if err := os.Chmod(dir, 0755); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to set permissions on staging directory %s: %w", dir, err)
+	}
+
+	slog.Debug("Created staging directory", "dir", dir)
+	return &Staging{Dir: dir, finalDir: finalDir}, nil
+}
+
+// Commit atomically renames the staging directory to the final directory.
+// Returns an error if the final directory already exists.
+func (s *Staging) Commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cleaned {
+		return fmt.Errorf("staging directory %s was already removed", s.Dir)
+	}
+
+	if _, err := os.Lstat(s.finalDir); err == nil {
+		return fmt.Errorf("installation directory %s already exists", s.finalDir)
+	}
+
+	if err := os.Rename(s.Dir, s.finalDir); err != nil {
+		return fmt.Errorf("failed to move %s into place: %w", s.finalDir, err)
+	}
+
+	slog.Debug("Moved staging directory into place", "from", s.Dir, "to", s.finalDir)
+	s.committed = true
+	return nil
+}
+
+// Cleanup removes the staging directory if it hasn't been committed.
+// This operation is idempotent and does nothing after a successful Commit.
+func (s *Staging) Cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.committed || s.cleaned {
+		return
+	}
+
+	slog.Debug("Removing staging directory", "dir", s.Dir)
+	_ = os.RemoveAll(s.Dir)
+	s.cleaned = true
+}
+
+// Rollback removes a committed installation from its final directory.
+// It is used when a later step (such as saving the registry) fails after Commit.
+func (s *Staging) Rollback() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.committed {
+		return nil
+	}
+
+	slog.Debug("Rolling back installation", "dir", s.finalDir)
+	if err := os.RemoveAll(s.finalDir); err != nil {
+		return fmt.Errorf("failed to roll back installation at %s: %w", s.finalDir, err)
+	}
+
+	s.committed = false
+	s.cleaned = true
+	return nil
+}
@@ -0,0 +1,117 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStagingCommit verifies that committed staging directories end up in place.
+func TestStagingCommit(t *testing.T) {
+	finalDir := filepath.Join(t.TempDir(), "terraform", "v1.6.0")
+
+	staging, err := NewStaging(finalDir)
+	if err != nil {
+		t.Fatalf("NewStaging() error = %v", err)
+	}
+	defer staging.Cleanup()
+
+	// Staging directory lives next to the final directory
+	if filepath.Dir(staging.Dir) != filepath.Dir(finalDir) {
+		t.Errorf("staging dir %s is not a sibling of %s", staging.Dir, finalDir)
+	}
+	if !strings.HasPrefix(filepath.Base(staging.Dir), StagingPrefix) {
+		t.Errorf("staging dir %s does not have prefix %q", staging.Dir, StagingPrefix)
+	}
+
+	binaryPath := filepath.Join(staging.Dir, "terraform")
+	if err := os.WriteFile(binaryPath, []byte("binary"), 0755); err != nil {
+		t.Fatalf("failed to write test binary: %v", err)
+	}
+
+	if err := staging.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(finalDir, "terraform")); err != nil {
+		t.Errorf("binary not found in final directory: %v", err)
+	}
+	if _, err := os.Stat(staging.Dir); !os.IsNotExist(err) {
+		t.Errorf("staging directory still exists after Commit()")
+	}
+
+	// Cleanup after commit must not remove the installation
+	staging.Cleanup()
+	if _, err := os.Stat(finalDir); err != nil {
+		t.Errorf("Cleanup() after Commit() removed final directory: %v", err)
+	}
+}
+
+// TestStagingCleanup verifies that uncommitted staging directories are removed.
+func TestStagingCleanup(t *testing.T) {
+	finalDir := filepath.Join(t.TempDir(), "tofu", "v1.6.2")
+
+	staging, err := NewStaging(finalDir)
+	if err != nil {
+		t.Fatalf("NewStaging() error = %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(staging.Dir, "partial"), []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	staging.Cleanup()
+	staging.Cleanup() // idempotent
+
+	if _, err := os.Stat(staging.Dir); !os.IsNotExist(err) {
+		t.Errorf("staging directory still exists after Cleanup()")
+	}
+	if _, err := os.Stat(finalDir); !os.IsNotExist(err) {
+		t.Errorf("final directory should not exist after Cleanup()")
+	}
+
+	if err := staging.Commit(); err == nil {
+		t.Error("Commit() after Cleanup() expected error, got nil")
+	}
+}
+
+// TestStagingCommitExisting verifies that an existing installation is never overwritten.
+func TestStagingCommitExisting(t *testing.T) {
+	finalDir := filepath.Join(t.TempDir(), "terragrunt", "v0.54.0")
+	if err := os.MkdirAll(finalDir, 0755); err != nil {
+		t.Fatalf("failed to create final dir: %v", err)
+	}
+
+	staging, err := NewStaging(finalDir)
+	if err != nil {
+		t.Fatalf("NewStaging() error = %v", err)
+	}
+	defer staging.Cleanup()
+
+	if err := staging.Commit(); err == nil {
+		t.Error("Commit() expected error when final directory exists, got nil")
+	}
+}
+
+// TestStagingRollback verifies that a committed installation can be removed again.
+func TestStagingRollback(t *testing.T) {
+	finalDir := filepath.Join(t.TempDir(), "terraform", "v1.6.0")
+
+	staging, err := NewStaging(finalDir)
+	if err != nil {
+		t.Fatalf("NewStaging() error = %v", err)
+	}
+
+	if err := staging.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if err := staging.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	if _, err := os.Stat(finalDir); !os.IsNotExist(err) {
+		t.Errorf("final directory still exists after Rollback()")
+	}
+}
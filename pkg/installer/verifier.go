@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"strings"
@@ -24,44 +25,89 @@ import (
 //   - The file cannot be read
 //   - The checksum doesn't match
 func VerifyChecksum(filePath, expectedSHA256 string) error {
+	// Compute the checksum
+	actualChecksum, err := ComputeSHA256(filePath)
+	if err != nil {
+		return err
+	}
+
+	// Normalize both checksums to lowercase for comparison
+	expectedSHA256 = strings.ToLower(strings.TrimSpace(expectedSHA256))
+	actualChecksum = strings.ToLower(actualChecksum)
+
+	// Compare checksums
+	if actualChecksum != expectedSHA256 {
+		return utils.NewUserError(
+			"Checksum verification failed",
+			fmt.Sprintf("Downloaded file checksum mismatch. Expected: %s, Got: %s", expectedSHA256, actualChecksum),
+			"The downloaded file may be corrupted or tampered with. Please try downloading again.",
+		).WithCode(utils.CodeChecksumMismatch)
+	}
+
+	return nil
+}
+
+// ComputeSHA256 returns the hexadecimal SHA256 checksum of a file.
+// The file is streamed through the hasher so large files are not loaded into memory.
+func ComputeSHA256(filePath string) (string, error) {
+	return computeHash(filePath, sha256.New())
+}
+
+// computeHash streams a file through hasher and returns the hexadecimal digest.
+func computeHash(filePath string, hasher hash.Hash) (string, error) {
 	// Open the file
 	file, err := os.Open(filePath)
 	if err != nil {
-		return utils.NewUserError(
+		return "", utils.NewUserError(
 			fmt.Sprintf("Failed to open file for checksum verification: %s", filePath),
 			err.Error(),
 			fmt.Sprintf("Ensure the file exists and is readable: %s", filePath),
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
 	defer func() { _ = file.Close() }()
 
-	// Create SHA256 hasher
-	hasher := sha256.New()
-
 	// Stream file content to hasher (memory efficient)
 	if _, err := io.Copy(hasher, file); err != nil {
-		return utils.NewUserError(
+		return "", utils.NewUserError(
 			fmt.Sprintf("Failed to read file for checksum verification: %s", filePath),
 			err.Error(),
 			"Ensure the file is not corrupted and is readable",
-		)
+		).WithCode(utils.CodeFilesystem)
 	}
 
-	// Compute the checksum
-	actualChecksum := hex.EncodeToString(hasher.Sum(nil))
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
 
-	// Normalize both checksums to lowercase for comparison
-	expectedSHA256 = strings.ToLower(strings.TrimSpace(expectedSHA256))
-	actualChecksum = strings.ToLower(actualChecksum)
+// ParseChecksumFile reads a checksum file (e.g. SHA256SUMS) and extracts the checksum for the given filename.
+// Checksum files follow the format: "checksum  filename" (two spaces between).
+func ParseChecksumFile(checksumPath, targetFilename string) (string, error) {
+	data, err := os.ReadFile(checksumPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum file: %w", err)
+	}
 
-	// Compare checksums
-	if actualChecksum != expectedSHA256 {
-		return utils.NewUserError(
-			"Checksum verification failed",
-			fmt.Sprintf("Downloaded file checksum mismatch. Expected: %s, Got: %s", expectedSHA256, actualChecksum),
-			"The downloaded file may be corrupted or tampered with. Please try downloading again.",
-		)
+	// Parse each line: "checksum  filename"
+	lines := strings.Split(string(data), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Split on whitespace
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+
+		checksum := parts[0]
+		// Binary-mode entries are written as "checksum *filename"
+		filename := strings.TrimPrefix(parts[1], "*")
+
+		if filename == targetFilename {
+			return checksum, nil
+		}
 	}
 
-	return nil
+	return "", fmt.Errorf("checksum not found for %s in checksums file", targetFilename)
 }
@@ -178,3 +178,28 @@ func TestVerifyChecksumCaseInsensitive(t *testing.T) {
 		t.Errorf("VerifyChecksum() should accept lowercase checksum, got error: %v", err)
 	}
 }
+
+// TestComputeSHA256 verifies checksum computation for local files.
+func TestComputeSHA256(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test-file")
+
+	if err := os.WriteFile(filePath, []byte("hello world"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	got, err := ComputeSHA256(filePath)
+	if err != nil {
+		t.Fatalf("ComputeSHA256() error = %v", err)
+	}
+
+	want := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if got != want {
+		t.Errorf("ComputeSHA256() = %q, want %q", got, want)
+	}
+
+	// Missing file should return an error
+	if _, err := ComputeSHA256(filepath.Join(tmpDir, "missing")); err == nil {
+		t.Error("ComputeSHA256() expected error for missing file, got nil")
+	}
+}
@@ -0,0 +1,193 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// ChecksumVerifier verifies an artifact against its entry in a checksum file
+// (e.g. SHA256SUMS or SHA512SUMS).
+type ChecksumVerifier struct {
+	algorithm string
+	newHash   func() hash.Hash
+	mandatory bool
+}
+
+// NewChecksumVerifier creates a checksum verifier for "sha256" or "sha512".
+// A mandatory verifier fails when there is no checksum file, even under config.PolicyDefault.
+func NewChecksumVerifier(algorithm string, mandatory bool) (*ChecksumVerifier, error) {
+	switch algorithm {
+	case "sha256":
+		return &ChecksumVerifier{algorithm: algorithm, newHash: sha256.New, mandatory: mandatory}, nil
+	case "sha512":
+		return &ChecksumVerifier{algorithm: algorithm, newHash: sha512.New, mandatory: mandatory}, nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
+
+// Name returns the checksum algorithm.
+func (c *ChecksumVerifier) Name() string { return c.algorithm }
+
+// Signature returns false; checksums verify the artifact itself.
+func (c *ChecksumVerifier) Signature() bool { return false }
+
+// Required reports whether a checksum file must be present.
+func (c *ChecksumVerifier) Required() bool { return c.mandatory }
+
+// Verify compares the artifact's checksum to the one listed in the checksum file.
+func (c *ChecksumVerifier) Verify(artifact *Artifact) (string, error) {
+	if err := checksumFileAvailable(artifact); err != nil {
+		return "", err
+	}
+
+	var expected string
+	var err error
+	for _, name := range artifact.Names {
+		expected, err = ParseChecksumFile(artifact.ChecksumFile, name)
+		if err == nil {
+			break
+		}
+	}
+	if expected == "" {
+		return "", utils.NewUserError(
+			"Failed to parse checksum file",
+			fmt.Sprintf("%v", err),
+			"The checksum file format may be invalid. Please report this issue.",
+		).WithCode(utils.CodeVerificationUnavailable)
+	}
+
+	actual, err := computeHash(artifact.Path, c.newHash())
+	if err != nil {
+		return "", err
+	}
+
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	if actual != expected {
+		return "", utils.NewUserError(
+			"Checksum verification failed",
+			fmt.Sprintf("%s mismatch for %s. Expected: %s, Got: %s", strings.ToUpper(c.algorithm), artifact.Path, expected, actual),
+			"The downloaded file may be corrupted or tampered with. Please try downloading again.",
+		).WithCode(utils.CodeChecksumMismatch)
+	}
+
+	return actual, nil
+}
+
+// GPGVerifier verifies the detached OpenPGP signature of the checksum file.
+type GPGVerifier struct {
+	SignatureURL  string   // If set, the signature is downloaded to SignaturePath first
+	SignaturePath string   // Defaults to "<checksum file>.sig"
+	Keys          [][]byte // ASCII-armored public keys to trust
+	Mandatory     bool     // Fail under config.PolicyDefault if the signature isn't available
+}
+
+// Name returns "gpg".
+func (g *GPGVerifier) Name() string { return "gpg" }
+
+// Signature returns true; the signature authenticates the checksum file.
+func (g *GPGVerifier) Signature() bool { return true }
+
+// Required reports whether the signature must be present.
+func (g *GPGVerifier) Required() bool { return g.Mandatory }
+
+// Verify checks the checksum file's signature and returns the signing key fingerprint.
+func (g *GPGVerifier) Verify(artifact *Artifact) (string, error) {
+	if err := checksumFileAvailable(artifact); err != nil {
+		return "", err
+	}
+
+	signaturePath, err := fetchMaterial(g.SignatureURL, g.SignaturePath, artifact.ChecksumFile+".sig")
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint, err := VerifyGPGSignature(artifact.ChecksumFile, signaturePath, g.Keys...)
+	if err != nil {
+		return "", err
+	}
+
+	return "key " + fingerprint, nil
+}
+
+// CosignVerifier verifies the keyless cosign signature of the checksum file.
+// A bundle is preferred; if there is none, the detached signature and
+// certificate are used, and their transparency log entry is looked up online.
+type CosignVerifier struct {
+	BundleURL       string // If set, the bundle is downloaded to BundlePath first
+	BundlePath      string // Defaults to "<checksum file>.sigstore.json", or "<checksum file>.bundle" if that exists
+	SignatureURL    string // If set, the signature is downloaded to SignaturePath first
+	SignaturePath   string // Defaults to "<checksum file>.sig"
+	CertificateURL  string // If set, the certificate is downloaded to CertificatePath first
+	CertificatePath string // Defaults to "<checksum file>.pem"
+	Trust           CosignTrust
+	Identity        CertificateIdentity
+	Mandatory       bool // Fail under config.PolicyDefault if the signature isn't available
+}
+
+// Name returns "cosign".
+func (c *CosignVerifier) Name() string { return "cosign" }
+
+// Signature returns true; the signature authenticates the checksum file.
+func (c *CosignVerifier) Signature() bool { return true }
+
+// Required reports whether the signature must be present.
+func (c *CosignVerifier) Required() bool { return c.Mandatory }
+
+// Verify checks the checksum file's signature and returns the signer identity.
+func (c *CosignVerifier) Verify(artifact *Artifact) (string, error) {
+	if err := checksumFileAvailable(artifact); err != nil {
+		return "", err
+	}
+
+	defaultBundle := artifact.ChecksumFile + ".sigstore.json"
+	if c.BundleURL == "" && materialAvailable(defaultBundle) != nil && materialAvailable(artifact.ChecksumFile+".bundle") == nil {
+		defaultBundle = artifact.ChecksumFile + ".bundle"
+	}
+	bundlePath, err := fetchMaterial(c.BundleURL, c.BundlePath, defaultBundle)
+	if err == nil {
+		return VerifyCosignBundle(artifact.ChecksumFile, bundlePath, c.Trust, c.Identity)
+	}
+	if !errors.Is(err, ErrUnavailable) {
+		return "", err
+	}
+
+	signaturePath, err := fetchMaterial(c.SignatureURL, c.SignaturePath, artifact.ChecksumFile+".sig")
+	if err != nil {
+		return "", err
+	}
+
+	certificatePath, err := fetchMaterial(c.CertificateURL, c.CertificatePath, artifact.ChecksumFile+".pem")
+	if err != nil {
+		return "", err
+	}
+
+	return VerifyCosignSignature(artifact.ChecksumFile, signaturePath, certificatePath, c.Trust, c.Identity)
+}
+
+// fetchMaterial returns the local path of a signature or certificate,
+// downloading it from url first if one is given.
+func fetchMaterial(url, path, defaultPath string) (string, error) {
+	if path == "" {
+		path = defaultPath
+	}
+
+	if url != "" {
+		if err := Download(url, path); err != nil {
+			return "", fmt.Errorf("%w: %s: %v", ErrUnavailable, url, err)
+		}
+		return path, nil
+	}
+
+	if err := materialAvailable(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,154 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestChecksumVerifier verifies SHA256 and SHA512 checks against checksum files.
+func TestChecksumVerifier(t *testing.T) {
+	content := []byte("tool binary")
+	sum256 := sha256.Sum256(content)
+	sum512 := sha512.Sum512(content)
+
+	tests := []struct {
+		name      string
+		algorithm string
+		sums      string
+		names     []string
+		wantErr   bool
+		wantUnav  bool
+	}{
+		{
+			name:      "sha256 match",
+			algorithm: "sha256",
+			sums:      hex.EncodeToString(sum256[:]) + "  tool.zip\n",
+			names:     []string{"tool.zip"},
+		},
+		{
+			name:      "sha512 match with binary-mode entry",
+			algorithm: "sha512",
+			sums:      hex.EncodeToString(sum512[:]) + " *tool.zip\n",
+			names:     []string{"tool.zip"},
+		},
+		{
+			name:      "falls back to alternative name",
+			algorithm: "sha256",
+			sums:      hex.EncodeToString(sum256[:]) + "  upstream.zip\n",
+			names:     []string{"renamed.zip", "upstream.zip"},
+		},
+		{
+			name:      "mismatch",
+			algorithm: "sha256",
+			sums:      "0000000000000000000000000000000000000000000000000000000000000000  tool.zip\n",
+			names:     []string{"tool.zip"},
+			wantErr:   true,
+		},
+		{
+			name:      "not listed",
+			algorithm: "sha256",
+			sums:      hex.EncodeToString(sum256[:]) + "  other.zip\n",
+			names:     []string{"tool.zip"},
+			wantErr:   true,
+		},
+		{
+			name:      "no checksum file",
+			algorithm: "sha256",
+			names:     []string{"tool.zip"},
+			wantErr:   true,
+			wantUnav:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			artifact := &Artifact{Path: filepath.Join(dir, "tool.zip"), Names: tt.names}
+			if err := os.WriteFile(artifact.Path, content, 0644); err != nil {
+				t.Fatalf("failed to write artifact: %v", err)
+			}
+			if tt.sums != "" {
+				artifact.ChecksumFile = filepath.Join(dir, "SUMS")
+				if err := os.WriteFile(artifact.ChecksumFile, []byte(tt.sums), 0644); err != nil {
+					t.Fatalf("failed to write checksums: %v", err)
+				}
+			}
+
+			v, err := NewChecksumVerifier(tt.algorithm, false)
+			if err != nil {
+				t.Fatalf("NewChecksumVerifier() error = %v", err)
+			}
+
+			_, err = v.Verify(artifact)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrUnavailable) != tt.wantUnav {
+				t.Errorf("Verify() unavailable = %v, want %v", errors.Is(err, ErrUnavailable), tt.wantUnav)
+			}
+		})
+	}
+}
+
+// TestNewChecksumVerifierUnsupported verifies unknown algorithms are rejected.
+func TestNewChecksumVerifierUnsupported(t *testing.T) {
+	if _, err := NewChecksumVerifier("md5", false); err == nil {
+		t.Error("NewChecksumVerifier(md5) expected error, got nil")
+	}
+}
+
+// TestSignatureVerifiersUnavailable verifies signature verifiers report missing local material.
+func TestSignatureVerifiersUnavailable(t *testing.T) {
+	dir := t.TempDir()
+	artifact := &Artifact{ChecksumFile: filepath.Join(dir, "SUMS")}
+	if err := os.WriteFile(artifact.ChecksumFile, []byte("abc  tool.zip\n"), 0644); err != nil {
+		t.Fatalf("failed to write checksums: %v", err)
+	}
+
+	for _, v := range []Verifier{&GPGVerifier{}, &CosignVerifier{}} {
+		t.Run(v.Name(), func(t *testing.T) {
+			if !v.Signature() {
+				t.Errorf("Signature() = false, want true")
+			}
+
+			_, err := v.Verify(artifact)
+			if !errors.Is(err, ErrUnavailable) {
+				t.Errorf("Verify() error = %v, want ErrUnavailable", err)
+			}
+
+			_, err = v.Verify(&Artifact{})
+			if !errors.Is(err, ErrUnavailable) {
+				t.Errorf("Verify() without checksum file error = %v, want ErrUnavailable", err)
+			}
+		})
+	}
+}
+
+// TestGPGVerifierLocalSignature verifies a signature found next to the checksum file.
+func TestGPGVerifierLocalSignature(t *testing.T) {
+	entity, publicKey := newTestKey(t, time.Now().Add(-time.Hour), 0)
+
+	dir := t.TempDir()
+	artifact := &Artifact{ChecksumFile: filepath.Join(dir, "SUMS")}
+	if err := os.WriteFile(artifact.ChecksumFile, []byte("abc  tool.zip\n"), 0644); err != nil {
+		t.Fatalf("failed to write checksums: %v", err)
+	}
+	signFile(t, entity, artifact.ChecksumFile, time.Now().Add(-time.Minute), false)
+
+	detail, err := (&GPGVerifier{Keys: [][]byte{publicKey}}).Verify(artifact)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	want := fmt.Sprintf("key %X", entity.PrimaryKey.Fingerprint)
+	if detail != want {
+		t.Errorf("Verify() = %q, want %q", detail, want)
+	}
+}
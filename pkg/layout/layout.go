@@ -0,0 +1,271 @@
+// Package layout moves a Binarius installation between directory layouts
+// (see paths.Layout), keeping the registry, config.yaml, and symlinks in step
+// with the files that move.
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/paths"
+	"github.com/nixknight/binarius/pkg/symlink"
+)
+
+// Move relocates a single file or directory.
+type Move struct {
+	From string
+	To   string
+}
+
+// String formats the move as "from -> to".
+func (m Move) String() string {
+	return fmt.Sprintf("%s -> %s", m.From, m.To)
+}
+
+// Entries of the config and data directories that belong to Binarius.
+// Anything else in those directories is left where it is.
+var (
+	configEntries = []string{"config.yaml", "config.yaml.bak-v*"}
+	dataEntries   = []string{"installation.json", "installation.json.bak-v*", "installation.json.corrupt-*", "tools"}
+)
+
+// Plan returns the moves that relocate an installation from one set of
+// directories to another. Every entry of from.Cache is moved; leave from.Cache
+// empty to keep the cache where it is.
+// Returns an error listing every destination that already exists.
+func Plan(from, to paths.Dirs) ([]Move, error) {
+	var moves []Move
+
+	add := func(fromDir, toDir string, patterns []string) error {
+		if fromDir == "" || filepath.Clean(fromDir) == filepath.Clean(toDir) {
+			return nil
+		}
+
+		for _, pattern := range patterns {
+			matches, err := filepath.Glob(filepath.Join(fromDir, pattern))
+			if err != nil {
+				return err
+			}
+			sort.Strings(matches)
+			for _, match := range matches {
+				moves = append(moves, Move{From: match, To: filepath.Join(toDir, filepath.Base(match))})
+			}
+		}
+		return nil
+	}
+
+	if err := add(from.Config, to.Config, configEntries); err != nil {
+		return nil, err
+	}
+	if err := add(from.Data, to.Data, dataEntries); err != nil {
+		return nil, err
+	}
+	if err := add(from.Cache, to.Cache, []string{"*"}); err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	for _, move := range moves {
+		if _, err := os.Lstat(move.To); err == nil {
+			conflicts = append(conflicts, move.To)
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("destination already exists: %s", strings.Join(conflicts, ", "))
+	}
+
+	return moves, nil
+}
+
+// Apply performs the moves in order. Moves across filesystems fall back to
+// copying and deleting. If a move fails, the moves already made are undone.
+func Apply(moves []Move) error {
+	for i, move := range moves {
+		if err := moveEntry(move.From, move.To); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				_ = moveEntry(moves[j].To, moves[j].From)
+			}
+			return fmt.Errorf("failed to move %s: %w", move.From, err)
+		}
+	}
+	return nil
+}
+
+// RewriteRegistry re-points binary paths under oldToolsDir to newToolsDir.
+// Returns the number of entries changed.
+func RewriteRegistry(registry *config.Registry, oldToolsDir, newToolsDir string) int {
+	changed := 0
+	for toolName, versions := range registry.Tools {
+		for version, tv := range versions {
+			if rel, ok := relativeTo(tv.BinaryPath, oldToolsDir); ok {
+				tv.BinaryPath = filepath.Join(newToolsDir, rel)
+				registry.AddVersion(toolName, version, tv)
+				changed++
+			}
+		}
+	}
+	return changed
+}
+
+// RewriteConfig clears directory paths in cfg that point at the default
+// directories of the old layout, so the defaults of the new layout apply.
+// Reports whether anything changed.
+func RewriteConfig(cfg *config.Config, from paths.Dirs) bool {
+	changed := false
+	unset := func(field *string, dir string) {
+		if *field == "" {
+			return
+		}
+		if expanded, err := paths.Expand(*field); err == nil && filepath.Clean(expanded) == filepath.Clean(dir) {
+			*field = ""
+			changed = true
+		}
+	}
+
+	unset(&cfg.Paths.BinariusHome, from.Data)
+	unset(&cfg.Paths.CacheDir, from.Cache)
+	return changed
+}
+
+// Relink re-points symlinks in binDir that target files under oldToolsDir to
+// the same files under newToolsDir. Returns the symlinks that were updated.
+func Relink(binDir, oldToolsDir, newToolsDir string) ([]string, error) {
+	entries, err := os.ReadDir(binDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", binDir, err)
+	}
+
+	manager := &symlink.Manager{}
+	var relinked []string
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+
+		link := filepath.Join(binDir, entry.Name())
+		target, err := os.Readlink(link)
+		if err != nil {
+			continue
+		}
+
+		rel, ok := relativeTo(target, oldToolsDir)
+		if !ok {
+			continue // Not one of ours
+		}
+
+		if err := manager.Update(filepath.Join(newToolsDir, rel), link); err != nil {
+			return relinked, err
+		}
+		relinked = append(relinked, link)
+	}
+
+	return relinked, nil
+}
+
+// RemoveEmpty removes each directory that is empty, deepest first, and returns
+// the directories that had to be left in place because they still have content.
+func RemoveEmpty(dirs ...string) []string {
+	sorted := append([]string(nil), dirs...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	var remaining []string
+	for _, dir := range sorted {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			remaining = append(remaining, dir)
+		}
+	}
+	sort.Strings(remaining)
+	return remaining
+}
+
+// relativeTo returns path relative to dir if path is inside dir.
+func relativeTo(path, dir string) (string, bool) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// moveEntry renames from to to, creating the parent of to. Moves between
+// filesystems are done by copying and then removing the original.
+func moveEntry(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+
+	err := os.Rename(from, to)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyTree(from, to); err != nil {
+		_ = os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+// copyTree copies a file, symlink, or directory tree, preserving permissions.
+func copyTree(from, to string) error {
+	info, err := os.Lstat(from)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(from)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, to)
+
+	case info.IsDir():
+		if err := os.MkdirAll(to, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(from)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := copyTree(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return copyFile(from, to, info.Mode().Perm())
+	}
+}
+
+func copyFile(from, to string, perm os.FileMode) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,278 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nixknight/binarius/pkg/config"
+	"github.com/nixknight/binarius/pkg/paths"
+)
+
+// writeFiles creates files (with parent directories) under root.
+func writeFiles(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func classicAndXDG(root string) (paths.Dirs, paths.Dirs) {
+	home := filepath.Join(root, ".binarius")
+	classic := paths.Dirs{Config: home, Data: home, Cache: filepath.Join(home, "cache")}
+	xdg := paths.Dirs{
+		Config: filepath.Join(root, ".config", "binarius"),
+		Data:   filepath.Join(root, ".local", "share", "binarius"),
+		Cache:  filepath.Join(root, ".cache", "binarius"),
+	}
+	return classic, xdg
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string // Relative to the temporary root
+		reverse bool     // Plan xdg -> classic instead of classic -> xdg
+		want    []Move   // Relative to the temporary root
+		wantErr bool
+	}{
+		{
+			name: "classic to xdg",
+			files: []string{
+				".binarius/config.yaml",
+				".binarius/config.yaml.bak-v1",
+				".binarius/installation.json",
+				".binarius/tools/terraform/v1.6.0/terraform",
+				".binarius/cache/terraform_1.6.0_linux_amd64.zip",
+				".binarius/notes.txt",
+			},
+			want: []Move{
+				{".binarius/config.yaml", ".config/binarius/config.yaml"},
+				{".binarius/config.yaml.bak-v1", ".config/binarius/config.yaml.bak-v1"},
+				{".binarius/installation.json", ".local/share/binarius/installation.json"},
+				{".binarius/tools", ".local/share/binarius/tools"},
+				{".binarius/cache/terraform_1.6.0_linux_amd64.zip", ".cache/binarius/terraform_1.6.0_linux_amd64.zip"},
+			},
+		},
+		{
+			name: "xdg to classic",
+			files: []string{
+				".config/binarius/config.yaml",
+				".local/share/binarius/installation.json",
+				".local/share/binarius/tools/tofu/v1.6.0/tofu",
+			},
+			reverse: true,
+			want: []Move{
+				{".config/binarius/config.yaml", ".binarius/config.yaml"},
+				{".local/share/binarius/installation.json", ".binarius/installation.json"},
+				{".local/share/binarius/tools", ".binarius/tools"},
+			},
+		},
+		{
+			name: "destination exists",
+			files: []string{
+				".binarius/installation.json",
+				".local/share/binarius/installation.json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing to move",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tt.files...)
+
+			from, to := classicAndXDG(root)
+			if tt.reverse {
+				from, to = to, from
+			}
+
+			got, err := Plan(from, to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Plan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var want []Move
+			for _, move := range tt.want {
+				want = append(want, Move{filepath.Join(root, move.From), filepath.Join(root, move.To)})
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Plan() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "a/config.yaml", "a/tools/terraform/v1.6.0/terraform")
+
+	moves := []Move{
+		{filepath.Join(root, "a", "config.yaml"), filepath.Join(root, "b", "config.yaml")},
+		{filepath.Join(root, "a", "tools"), filepath.Join(root, "c", "tools")},
+	}
+	if err := Apply(moves); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for _, path := range []string{"b/config.yaml", "c/tools/terraform/v1.6.0/terraform"} {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			t.Errorf("Expected %s after Apply(): %v", path, err)
+		}
+	}
+
+	// A failing move undoes the earlier ones
+	moves = []Move{
+		{filepath.Join(root, "b", "config.yaml"), filepath.Join(root, "d", "config.yaml")},
+		{filepath.Join(root, "missing"), filepath.Join(root, "d", "missing")},
+	}
+	if err := Apply(moves); err == nil {
+		t.Fatal("Apply() with a missing source should fail")
+	}
+	if _, err := os.Stat(filepath.Join(root, "b", "config.yaml")); err != nil {
+		t.Errorf("Expected failed Apply() to restore b/config.yaml: %v", err)
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "src/tools/terraform/v1.6.0/terraform")
+	binary := filepath.Join(root, "src", "tools", "terraform", "v1.6.0", "terraform")
+	if err := os.Chmod(binary, 0755); err != nil {
+		t.Fatalf("Failed to chmod: %v", err)
+	}
+	if err := os.Symlink("v1.6.0", filepath.Join(root, "src", "tools", "terraform", "latest")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	if err := copyTree(filepath.Join(root, "src"), filepath.Join(root, "dst")); err != nil {
+		t.Fatalf("copyTree() error = %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(root, "dst", "tools", "terraform", "v1.6.0", "terraform"))
+	if err != nil {
+		t.Fatalf("Copied binary missing: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Copied binary mode = %o, want 755", info.Mode().Perm())
+	}
+
+	target, err := os.Readlink(filepath.Join(root, "dst", "tools", "terraform", "latest"))
+	if err != nil || target != "v1.6.0" {
+		t.Errorf("Copied symlink = %q (%v), want v1.6.0", target, err)
+	}
+}
+
+func TestRewriteRegistry(t *testing.T) {
+	registry := config.NewRegistry()
+	registry.AddVersion("terraform", "v1.6.0", config.ToolVersion{BinaryPath: "/old/tools/terraform/v1.6.0/terraform"})
+	registry.AddVersion("tofu", "v1.6.0", config.ToolVersion{BinaryPath: "/elsewhere/tofu/v1.6.0/tofu"})
+
+	if got := RewriteRegistry(registry, "/old/tools", "/new/tools"); got != 1 {
+		t.Errorf("RewriteRegistry() = %d, want 1", got)
+	}
+
+	if got := registry.GetVersion("terraform", "v1.6.0").BinaryPath; got != "/new/tools/terraform/v1.6.0/terraform" {
+		t.Errorf("terraform BinaryPath = %q", got)
+	}
+	if got := registry.GetVersion("tofu", "v1.6.0").BinaryPath; got != "/elsewhere/tofu/v1.6.0/tofu" {
+		t.Errorf("tofu BinaryPath = %q, want it unchanged", got)
+	}
+}
+
+func TestRewriteConfig(t *testing.T) {
+	from := paths.Dirs{Config: "/old", Data: "/old", Cache: "/old/cache"}
+
+	tests := []struct {
+		name        string
+		paths       config.PathConfig
+		want        config.PathConfig
+		wantChanged bool
+	}{
+		{
+			name:        "old defaults are cleared",
+			paths:       config.PathConfig{BinariusHome: "/old", BinDir: "/bin", CacheDir: "/old/cache/"},
+			want:        config.PathConfig{BinDir: "/bin"},
+			wantChanged: true,
+		},
+		{
+			name:  "custom paths are kept",
+			paths: config.PathConfig{BinariusHome: "/data", CacheDir: "/var/cache"},
+			want:  config.PathConfig{BinariusHome: "/data", CacheDir: "/var/cache"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Paths: tt.paths}
+			if changed := RewriteConfig(cfg, from); changed != tt.wantChanged {
+				t.Errorf("RewriteConfig() = %v, want %v", changed, tt.wantChanged)
+			}
+			if cfg.Paths != tt.want {
+				t.Errorf("Paths = %+v, want %+v", cfg.Paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelink(t *testing.T) {
+	root := t.TempDir()
+	oldTools := filepath.Join(root, "old", "tools")
+	newTools := filepath.Join(root, "new", "tools")
+	binDir := filepath.Join(root, "bin")
+	writeFiles(t, root, "new/tools/terraform/v1.6.0/terraform", "other/kubectl")
+
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("Failed to create bin dir: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(oldTools, "terraform", "v1.6.0", "terraform"), filepath.Join(binDir, "terraform")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, "other", "kubectl"), filepath.Join(binDir, "kubectl")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	relinked, err := Relink(binDir, oldTools, newTools)
+	if err != nil {
+		t.Fatalf("Relink() error = %v", err)
+	}
+	if want := []string{filepath.Join(binDir, "terraform")}; !reflect.DeepEqual(relinked, want) {
+		t.Errorf("Relink() = %v, want %v", relinked, want)
+	}
+
+	target, _ := os.Readlink(filepath.Join(binDir, "terraform"))
+	if want := filepath.Join(newTools, "terraform", "v1.6.0", "terraform"); target != want {
+		t.Errorf("terraform symlink = %q, want %q", target, want)
+	}
+	target, _ = os.Readlink(filepath.Join(binDir, "kubectl"))
+	if want := filepath.Join(root, "other", "kubectl"); target != want {
+		t.Errorf("kubectl symlink = %q, want it unchanged", target)
+	}
+}
+
+func TestRemoveEmpty(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "keep/notes.txt")
+	if err := os.MkdirAll(filepath.Join(root, "empty", "cache"), 0755); err != nil {
+		t.Fatalf("Failed to create dirs: %v", err)
+	}
+
+	remaining := RemoveEmpty(filepath.Join(root, "empty"), filepath.Join(root, "empty", "cache"), filepath.Join(root, "keep"), filepath.Join(root, "missing"))
+	if want := []string{filepath.Join(root, "keep")}; !reflect.DeepEqual(remaining, want) {
+		t.Errorf("RemoveEmpty() = %v, want %v", remaining, want)
+	}
+	if _, err := os.Stat(filepath.Join(root, "empty")); !os.IsNotExist(err) {
+		t.Errorf("Expected empty directory tree to be removed")
+	}
+}
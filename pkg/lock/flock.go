@@ -0,0 +1,115 @@
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// FileName is the name of the lock file created inside the locked directory.
+const FileName = ".binarius.lock"
+
+// pollInterval is how often a busy lock is retried while waiting.
+const pollInterval = 100 * time.Millisecond
+
+// ErrTimeout is returned when the lock could not be acquired before the timeout.
+var ErrTimeout = errors.New("timed out waiting for lock")
+
+// Lock is an advisory, cross-process exclusive lock on a directory backed by flock(2).
+// The kernel releases the lock automatically if the holding process dies.
+type Lock struct {
+	file *os.File
+}
+
+// Acquire takes an exclusive lock on dir, waiting up to timeout for other
+// processes to release it. The lock file records the PID of the holder.
+//
+// Parameters:
+//   - dir: The directory to lock (created if it doesn't exist)
+//   - timeout: How long to wait for a busy lock before giving up
+//   - onWait: Called once with the holder's PID (0 if unknown) when the lock is busy; may be nil
+func Acquire(dir string, timeout time.Duration, onWait func(pid int)) (*Lock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, FileName)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", path, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	notified := false
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, syscall.EWOULDBLOCK) && !errors.Is(err, syscall.EINTR) {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+
+		if !notified && onWait != nil {
+			onWait(HolderPID(dir))
+			notified = true
+		}
+
+		if time.Now().After(deadline) {
+			_ = file.Close()
+			return nil, fmt.Errorf("%w on %s after %s", ErrTimeout, path, timeout)
+		}
+
+		time.Sleep(pollInterval)
+	}
+
+	// Record our PID for processes waiting on the lock
+	if err := file.Truncate(0); err == nil {
+		_, _ = file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+
+	return &Lock{file: file}, nil
+}
+
+// Release unlocks and closes the lock file.
+// This operation is idempotent.
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	// Clear the PID before unlocking so waiters don't report a stale holder
+	_ = l.file.Truncate(0)
+
+	unlockErr := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	closeErr := l.file.Close()
+	l.file = nil
+
+	if unlockErr != nil {
+		return fmt.Errorf("failed to unlock: %w", unlockErr)
+	}
+	return closeErr
+}
+
+// HolderPID returns the PID recorded in the lock file of dir,
+// or 0 if the file doesn't exist or doesn't contain a PID.
+func HolderPID(dir string) int {
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		return 0
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+
+	return pid
+}
@@ -0,0 +1,77 @@
+package lock
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestAcquireRelease verifies basic lock acquisition and release.
+func TestAcquireRelease(t *testing.T) {
+	dir := t.TempDir()
+
+	l, err := Acquire(dir, time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	if pid := HolderPID(dir); pid != os.Getpid() {
+		t.Errorf("HolderPID() = %d, want %d", pid, os.Getpid())
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	// Release is idempotent
+	if err := l.Release(); err != nil {
+		t.Errorf("second Release() error = %v", err)
+	}
+
+	if pid := HolderPID(dir); pid != 0 {
+		t.Errorf("HolderPID() after Release() = %d, want 0", pid)
+	}
+}
+
+// TestAcquireTimeout verifies that a held lock blocks other acquirers until the timeout.
+func TestAcquireTimeout(t *testing.T) {
+	dir := t.TempDir()
+
+	held, err := Acquire(dir, time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer func() { _ = held.Release() }()
+
+	waitedFor := -1
+	_, err = Acquire(dir, 300*time.Millisecond, func(pid int) { waitedFor = pid })
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Acquire() error = %v, want ErrTimeout", err)
+	}
+
+	if waitedFor != os.Getpid() {
+		t.Errorf("onWait() called with PID %d, want %d", waitedFor, os.Getpid())
+	}
+}
+
+// TestAcquireWaitsForRelease verifies that a waiter gets the lock once it is released.
+func TestAcquireWaitsForRelease(t *testing.T) {
+	dir := t.TempDir()
+
+	held, err := Acquire(dir, time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = held.Release()
+	}()
+
+	l, err := Acquire(dir, 5*time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	_ = l.Release()
+}
@@ -0,0 +1,126 @@
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Layout is a scheme for where Binarius keeps its files by default.
+type Layout string
+
+const (
+	// LayoutClassic keeps config, registry, tools, and cache in ~/.binarius.
+	LayoutClassic Layout = "classic"
+	// LayoutXDG follows the XDG Base Directory specification: config in
+	// $XDG_CONFIG_HOME/binarius, registry and tools in $XDG_DATA_HOME/binarius,
+	// and downloads in $XDG_CACHE_HOME/binarius.
+	LayoutXDG Layout = "xdg"
+)
+
+// Dirs are the default directories of a layout.
+type Dirs struct {
+	Config string // Holds config.yaml
+	Data   string // Holds installation.json and tools/
+	Cache  string // Holds downloaded archives
+}
+
+// ParseLayout validates a layout name.
+func ParseLayout(name string) (Layout, error) {
+	switch Layout(strings.ToLower(name)) {
+	case LayoutClassic:
+		return LayoutClassic, nil
+	case LayoutXDG:
+		return LayoutXDG, nil
+	default:
+		return "", fmt.Errorf("unknown layout %q (expected %q or %q)", name, LayoutClassic, LayoutXDG)
+	}
+}
+
+// DefaultDirs returns the default directories of a layout.
+func DefaultDirs(layout Layout) (Dirs, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return Dirs{}, err
+	}
+
+	if layout == LayoutXDG {
+		return Dirs{
+			Config: filepath.Join(xdgDir("XDG_CONFIG_HOME", homeDir, ".config"), "binarius"),
+			Data:   filepath.Join(xdgDir("XDG_DATA_HOME", homeDir, ".local", "share"), "binarius"),
+			Cache:  filepath.Join(xdgDir("XDG_CACHE_HOME", homeDir, ".cache"), "binarius"),
+		}, nil
+	}
+
+	home := filepath.Join(homeDir, ".binarius")
+	return Dirs{Config: home, Data: home, Cache: filepath.Join(home, "cache")}, nil
+}
+
+// CurrentLayout returns the layout in effect:
+//
+//  1. BINARIUS_LAYOUT ("classic" or "xdg"), if set
+//  2. classic, if ~/.binarius exists, so existing installations keep working
+//     until they're moved with 'binarius migrate-layout'
+//  3. xdg, if Binarius' XDG config or data directory exists
+//  4. xdg, if XDG_CONFIG_HOME, XDG_DATA_HOME, or XDG_CACHE_HOME is set
+//  5. classic otherwise
+//
+// The layout only decides defaults; --home, BINARIUS_HOME, and the other
+// overrides still take precedence.
+func CurrentLayout() (Layout, error) {
+	if name := os.Getenv("BINARIUS_LAYOUT"); name != "" {
+		layout, err := ParseLayout(name)
+		if err != nil {
+			return "", fmt.Errorf("invalid BINARIUS_LAYOUT: %w", err)
+		}
+		return layout, nil
+	}
+
+	classic, err := DefaultDirs(LayoutClassic)
+	if err != nil {
+		return "", err
+	}
+	if exists(classic.Data) {
+		return LayoutClassic, nil
+	}
+
+	xdg, err := DefaultDirs(LayoutXDG)
+	if err != nil {
+		return "", err
+	}
+	if exists(xdg.Config) || exists(xdg.Data) {
+		return LayoutXDG, nil
+	}
+
+	for _, key := range []string{"XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_CACHE_HOME"} {
+		if os.Getenv(key) != "" {
+			return LayoutXDG, nil
+		}
+	}
+
+	return LayoutClassic, nil
+}
+
+// currentDirs returns the default directories of the current layout.
+func currentDirs() (Dirs, error) {
+	layout, err := CurrentLayout()
+	if err != nil {
+		return Dirs{}, err
+	}
+	return DefaultDirs(layout)
+}
+
+// xdgDir returns the value of an XDG base directory variable, or its default
+// under homeDir. Relative values are invalid per the specification and ignored.
+func xdgDir(envVar, homeDir string, fallback ...string) string {
+	if dir := os.Getenv(envVar); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{homeDir}, fallback...)...)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
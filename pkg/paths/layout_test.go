@@ -0,0 +1,141 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCurrentLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		dirs    []string // Created under the temporary home directory
+		want    Layout
+		wantErr bool
+	}{
+		{
+			name: "nothing set",
+			want: LayoutClassic,
+		},
+		{
+			name: "explicit xdg",
+			env:  map[string]string{"BINARIUS_LAYOUT": "xdg"},
+			dirs: []string{".binarius"},
+			want: LayoutXDG,
+		},
+		{
+			name: "explicit classic",
+			env:  map[string]string{"BINARIUS_LAYOUT": "Classic", "XDG_DATA_HOME": "/xdg/data"},
+			want: LayoutClassic,
+		},
+		{
+			name:    "invalid layout",
+			env:     map[string]string{"BINARIUS_LAYOUT": "flat"},
+			wantErr: true,
+		},
+		{
+			name: "existing home keeps classic layout",
+			env:  map[string]string{"XDG_CONFIG_HOME": "/xdg/config"},
+			dirs: []string{".binarius"},
+			want: LayoutClassic,
+		},
+		{
+			name: "existing xdg data directory",
+			dirs: []string{".local/share/binarius"},
+			want: LayoutXDG,
+		},
+		{
+			name: "xdg variable set",
+			env:  map[string]string{"XDG_CACHE_HOME": "/xdg/cache"},
+			want: LayoutXDG,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			for _, key := range []string{"BINARIUS_LAYOUT", "XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_CACHE_HOME"} {
+				t.Setenv(key, tt.env[key])
+			}
+			for _, dir := range tt.dirs {
+				if err := os.MkdirAll(filepath.Join(home, dir), 0755); err != nil {
+					t.Fatalf("Failed to create %s: %v", dir, err)
+				}
+			}
+
+			got, err := CurrentLayout()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CurrentLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CurrentLayout() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXDGLayoutDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("BINARIUS_LAYOUT", "xdg")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg/config")
+	t.Setenv("XDG_DATA_HOME", "relative/data") // Invalid per the spec, so ignored
+	t.Setenv("XDG_CACHE_HOME", "")
+
+	checks := []struct {
+		name string
+		fn   func() (string, error)
+		want string
+	}{
+		{"ConfigFile", ConfigFile, "/xdg/config/binarius/config.yaml"},
+		{"BinariusHome", BinariusHome, filepath.Join(home, ".local", "share", "binarius")},
+		{"RegistryFile", RegistryFile, filepath.Join(home, ".local", "share", "binarius", "installation.json")},
+		{"ToolsDir", ToolsDir, filepath.Join(home, ".local", "share", "binarius", "tools")},
+		{"CacheDir", CacheDir, filepath.Join(home, ".cache", "binarius")},
+		{"BinDir", BinDir, filepath.Join(home, ".local", "bin")},
+	}
+
+	for _, check := range checks {
+		got, err := check.fn()
+		if err != nil {
+			t.Fatalf("%s() error = %v", check.name, err)
+		}
+		if got != check.want {
+			t.Errorf("%s() = %v, want %v", check.name, got, check.want)
+		}
+	}
+
+	// An explicit home directory holds everything, like the classic layout
+	t.Setenv("BINARIUS_HOME", "/explicit")
+	if got, _ := ConfigFile(); got != "/explicit/config.yaml" {
+		t.Errorf("ConfigFile() with BINARIUS_HOME = %v, want /explicit/config.yaml", got)
+	}
+	if got, _ := CacheDir(); got != "/explicit/cache" {
+		t.Errorf("CacheDir() with BINARIUS_HOME = %v, want /explicit/cache", got)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Layout
+		wantErr bool
+	}{
+		{"classic", LayoutClassic, false},
+		{"XDG", LayoutXDG, false},
+		{"", "", true},
+		{"flat", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLayout(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLayout(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLayout(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
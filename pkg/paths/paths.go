@@ -4,26 +4,148 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
-// BinariusHome returns the absolute path to the Binarius home directory.
-// Defaults to ~/.binarius, expandable via BINARIUS_HOME environment variable.
+// Overrides holds directory paths set by a source other than the environment,
+// such as command-line flags or config.yaml. Empty fields are unset.
+type Overrides struct {
+	Home     string // Binarius home directory
+	BinDir   string // Symlink directory
+	CacheDir string // Downloaded archives cache directory
+}
+
+// Directories are resolved with this precedence, highest first:
+//
+//  1. Command-line flags (SetFlags)
+//  2. Environment variables (BINARIUS_HOME, BINARIUS_BIN_DIR, BINARIUS_CACHE_DIR)
+//  3. config.yaml (SetConfig)
+//  4. Defaults of the current Layout
+var (
+	mu              sync.RWMutex
+	flagOverrides   Overrides
+	configOverrides Overrides
+)
+
+// SetFlags sets the directories given on the command line.
+// Relative paths are made absolute against the current working directory.
+func SetFlags(o Overrides) error {
+	for _, field := range []*string{&o.Home, &o.BinDir, &o.CacheDir} {
+		if *field == "" {
+			continue
+		}
+
+		expanded, err := expandPath(*field)
+		if err != nil {
+			return err
+		}
+
+		if *field, err = filepath.Abs(expanded); err != nil {
+			return err
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	flagOverrides = o
+	return nil
+}
+
+// SetConfig sets the directories configured in config.yaml.
+func SetConfig(o Overrides) {
+	mu.Lock()
+	defer mu.Unlock()
+	configOverrides = o
+}
+
+// overrides returns the current flag and config.yaml overrides.
+func overrides() (Overrides, Overrides) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return flagOverrides, configOverrides
+}
+
+// resolve returns the first set value out of a flag, an environment variable,
+// and a config.yaml setting, with a leading tilde expanded.
+// Returns "" if none of them is set.
+func resolve(flag, envVar, configured string) (string, error) {
+	for _, value := range []string{flag, os.Getenv(envVar), configured} {
+		if value != "" {
+			return expandPath(value)
+		}
+	}
+	return "", nil
+}
+
+// BinariusHome returns the absolute path to the Binarius home directory, which
+// holds the installation registry and installed tools (and, in the classic
+// layout, the cache). Defaults to the data directory of the current Layout;
+// overridden by --home, BINARIUS_HOME, or paths.binarius_home in config.yaml.
 func BinariusHome() (string, error) {
-	if home := os.Getenv("BINARIUS_HOME"); home != "" {
-		return expandPath(home)
+	flags, configured := overrides()
+	if home, err := resolve(flags.Home, "BINARIUS_HOME", configured.Home); home != "" || err != nil {
+		return home, err
 	}
-	homeDir, err := os.UserHomeDir()
+
+	dirs, err := currentDirs()
+	if err != nil {
+		return "", err
+	}
+	return dirs.Data, nil
+}
+
+// ConfigFile returns the absolute path to config.yaml.
+// It lives in the home directory selected by --home or BINARIUS_HOME, or in the
+// config directory of the current Layout; paths.binarius_home inside config.yaml
+// can't move config.yaml itself.
+func ConfigFile() (string, error) {
+	flags, _ := overrides()
+	home, err := resolve(flags.Home, "BINARIUS_HOME", "")
+	if err != nil {
+		return "", err
+	}
+	if home != "" {
+		return filepath.Join(home, "config.yaml"), nil
+	}
+
+	dirs, err := currentDirs()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirs.Config, "config.yaml"), nil
+}
+
+// DefaultSystemConfigFile is the admin-managed config.yaml layered under the user's.
+const DefaultSystemConfigFile = "/etc/binarius/config.yaml"
+
+// SystemConfigFile returns the absolute path to the system-wide config.yaml.
+// Defaults to DefaultSystemConfigFile; overridden by BINARIUS_SYSTEM_CONFIG.
+func SystemConfigFile() (string, error) {
+	path, err := resolve("", "BINARIUS_SYSTEM_CONFIG", "")
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return DefaultSystemConfigFile, nil
+	}
+	return path, nil
+}
+
+// RegistryFile returns the absolute path to the installation registry.
+func RegistryFile() (string, error) {
+	home, err := BinariusHome()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".binarius"), nil
+	return filepath.Join(home, "installation.json"), nil
 }
 
 // BinDir returns the absolute path to the directory containing tool symlinks.
-// Defaults to ~/.local/bin, expandable via BINARIUS_BIN_DIR environment variable.
+// Defaults to ~/.local/bin; overridden by --bin-dir, BINARIUS_BIN_DIR, or paths.bin_dir in config.yaml.
 func BinDir() (string, error) {
-	if binDir := os.Getenv("BINARIUS_BIN_DIR"); binDir != "" {
-		return expandPath(binDir)
+	flags, configured := overrides()
+	if binDir, err := resolve(flags.BinDir, "BINARIUS_BIN_DIR", configured.BinDir); binDir != "" || err != nil {
+		return binDir, err
 	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -33,16 +155,28 @@ func BinDir() (string, error) {
 }
 
 // CacheDir returns the absolute path to the cache directory for downloaded archives.
-// Defaults to ~/.binarius/cache, expandable via BINARIUS_CACHE_DIR environment variable.
+// Defaults to the cache directory under an explicitly set home directory, or to
+// the cache directory of the current Layout; overridden by --cache-dir,
+// BINARIUS_CACHE_DIR, or paths.cache_dir in config.yaml.
 func CacheDir() (string, error) {
-	if cacheDir := os.Getenv("BINARIUS_CACHE_DIR"); cacheDir != "" {
-		return expandPath(cacheDir)
+	flags, configured := overrides()
+	if cacheDir, err := resolve(flags.CacheDir, "BINARIUS_CACHE_DIR", configured.CacheDir); cacheDir != "" || err != nil {
+		return cacheDir, err
 	}
-	home, err := BinariusHome()
+
+	home, err := resolve(flags.Home, "BINARIUS_HOME", configured.Home)
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "cache"), nil
+	if home != "" {
+		return filepath.Join(home, "cache"), nil
+	}
+
+	dirs, err := currentDirs()
+	if err != nil {
+		return "", err
+	}
+	return dirs.Cache, nil
 }
 
 // ToolsDir returns the absolute path to the directory containing installed tool binaries.
@@ -55,6 +189,11 @@ func ToolsDir() (string, error) {
 	return filepath.Join(home, "tools"), nil
 }
 
+// Expand expands a leading tilde (~) in a user-supplied path to the user's home directory.
+func Expand(path string) (string, error) {
+	return expandPath(path)
+}
+
 // expandPath expands tilde (~) prefixes to the user's home directory.
 // If the path doesn't start with ~, it's returned as-is.
 // Returns an error if the home directory cannot be determined.
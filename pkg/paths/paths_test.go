@@ -266,3 +266,133 @@ func TestExpandPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPrecedence(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("Failed to get user home directory: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		flags        Overrides
+		env          map[string]string
+		configured   Overrides
+		wantHome     string
+		wantBinDir   string
+		wantCacheDir string
+		wantConfig   string
+	}{
+		{
+			name:         "defaults",
+			wantHome:     filepath.Join(homeDir, ".binarius"),
+			wantBinDir:   filepath.Join(homeDir, ".local", "bin"),
+			wantCacheDir: filepath.Join(homeDir, ".binarius", "cache"),
+			wantConfig:   filepath.Join(homeDir, ".binarius", "config.yaml"),
+		},
+		{
+			name:         "config.yaml overrides defaults",
+			configured:   Overrides{Home: "/data/binarius", BinDir: "~/bin", CacheDir: "/var/cache/binarius"},
+			wantHome:     "/data/binarius",
+			wantBinDir:   filepath.Join(homeDir, "bin"),
+			wantCacheDir: "/var/cache/binarius",
+			wantConfig:   filepath.Join(homeDir, ".binarius", "config.yaml"),
+		},
+		{
+			name:         "cache follows configured home",
+			configured:   Overrides{Home: "/data/binarius"},
+			wantHome:     "/data/binarius",
+			wantBinDir:   filepath.Join(homeDir, ".local", "bin"),
+			wantCacheDir: "/data/binarius/cache",
+			wantConfig:   filepath.Join(homeDir, ".binarius", "config.yaml"),
+		},
+		{
+			name:         "environment overrides config.yaml",
+			env:          map[string]string{"BINARIUS_HOME": "/env/home", "BINARIUS_BIN_DIR": "/env/bin"},
+			configured:   Overrides{Home: "/data/binarius", BinDir: "/config/bin", CacheDir: "/config/cache"},
+			wantHome:     "/env/home",
+			wantBinDir:   "/env/bin",
+			wantCacheDir: "/config/cache",
+			wantConfig:   "/env/home/config.yaml",
+		},
+		{
+			name:         "flags override environment",
+			flags:        Overrides{Home: "/flag/home", CacheDir: "/flag/cache"},
+			env:          map[string]string{"BINARIUS_HOME": "/env/home", "BINARIUS_CACHE_DIR": "/env/cache"},
+			configured:   Overrides{BinDir: "/config/bin"},
+			wantHome:     "/flag/home",
+			wantBinDir:   "/config/bin",
+			wantCacheDir: "/flag/cache",
+			wantConfig:   "/flag/home/config.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if err := SetFlags(tt.flags); err != nil {
+				t.Fatalf("SetFlags() error = %v", err)
+			}
+			SetConfig(tt.configured)
+			t.Cleanup(func() {
+				_ = SetFlags(Overrides{})
+				SetConfig(Overrides{})
+			})
+
+			checks := []struct {
+				name string
+				fn   func() (string, error)
+				want string
+			}{
+				{"BinariusHome", BinariusHome, tt.wantHome},
+				{"BinDir", BinDir, tt.wantBinDir},
+				{"CacheDir", CacheDir, tt.wantCacheDir},
+				{"ConfigFile", ConfigFile, tt.wantConfig},
+			}
+			for _, check := range checks {
+				got, err := check.fn()
+				if err != nil {
+					t.Fatalf("%s() error = %v", check.name, err)
+				}
+				if got != check.want {
+					t.Errorf("%s() = %v, want %v", check.name, got, check.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetFlags_RelativePath(t *testing.T) {
+	t.Cleanup(func() { _ = SetFlags(Overrides{}) })
+
+	if err := SetFlags(Overrides{Home: "relative/home"}); err != nil {
+		t.Fatalf("SetFlags() error = %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	got, err := BinariusHome()
+	if err != nil {
+		t.Fatalf("BinariusHome() error = %v", err)
+	}
+	if want := filepath.Join(wd, "relative", "home"); got != want {
+		t.Errorf("BinariusHome() = %v, want %v", got, want)
+	}
+}
+
+func TestSystemConfigFile(t *testing.T) {
+	t.Setenv("BINARIUS_SYSTEM_CONFIG", "")
+	if got, err := SystemConfigFile(); err != nil || got != DefaultSystemConfigFile {
+		t.Errorf("SystemConfigFile() = %q, %v; want %q", got, err, DefaultSystemConfigFile)
+	}
+
+	t.Setenv("BINARIUS_SYSTEM_CONFIG", "/opt/binarius/config.yaml")
+	if got, err := SystemConfigFile(); err != nil || got != "/opt/binarius/config.yaml" {
+		t.Errorf("SystemConfigFile() = %q, %v; want the BINARIUS_SYSTEM_CONFIG value", got, err)
+	}
+}
@@ -0,0 +1,198 @@
+// Package prune decides which installed versions of a tool 'binarius prune'
+// removes, and finds the versions that projects pin in version files.
+package prune
+
+import (
+	"bufio"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nixknight/binarius/internal/utils"
+)
+
+// MaxDepth is how many directory levels below a project directory are
+// searched for version files.
+const MaxDepth = 3
+
+// ToolVersionsFile is the asdf-style file listing "<tool> <version>" lines.
+const ToolVersionsFile = ".tool-versions"
+
+// Pin is a version that a project file asks for.
+type Pin struct {
+	Tool    string
+	Version string // Normalized, e.g. "v1.6.0"
+	File    string // Version file the pin was read from
+}
+
+// VersionFileName returns the name of the file that pins a tool's version in
+// a project, e.g. ".terraform-version".
+func VersionFileName(tool string) string {
+	return "." + tool + "-version"
+}
+
+// otherPinNames lists the names other version managers use for a tool when
+// they differ from its Binarius name: tofuenv reads .opentofu-version, and
+// the asdf plugin is called opentofu.
+var otherPinNames = map[string][]string{
+	"tofu": {"opentofu"},
+}
+
+// PinNames returns the names a tool is pinned under in version files and
+// .tool-versions: its Binarius name, followed by the names other version
+// managers use.
+func PinNames(tool string) []string {
+	return append([]string{tool}, otherPinNames[tool]...)
+}
+
+// Plan returns the versions to remove so that only the keep newest versions
+// remain, by semantic version order. Protected versions are never removed, so
+// more than keep versions remain when older ones are protected. The result is
+// sorted oldest first.
+func Plan(versions []string, keep int, protected map[string]bool) []string {
+	sorted := append([]string(nil), versions...)
+	sort.Slice(sorted, func(i, j int) bool { return utils.CompareVersions(sorted[i], sorted[j]) > 0 })
+
+	var remove []string
+	kept := 0
+	for _, version := range sorted {
+		switch {
+		case kept < keep:
+			kept++
+		case protected[version]:
+		default:
+			remove = append(remove, version)
+		}
+	}
+
+	sort.Slice(remove, func(i, j int) bool { return utils.CompareVersions(remove[i], remove[j]) < 0 })
+	return remove
+}
+
+// FindPins reads the version files in each directory and its subdirectories
+// up to MaxDepth levels down, skipping hidden directories. Directories that
+// don't exist or can't be read are skipped.
+func FindPins(dirs, tools []string) []Pin {
+	var pins []Pin
+	for _, root := range dirs {
+		root = filepath.Clean(root)
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				slog.Debug("Skipping project directory", "path", path, "error", err)
+				if d != nil && d.IsDir() && path != root {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+
+			pins = append(pins, readPins(path, tools)...)
+
+			if depth(root, path) >= MaxDepth {
+				return fs.SkipDir
+			}
+			return nil
+		})
+	}
+	return pins
+}
+
+// FindPinsUpward reads the version files in dir and each of its parents, the
+// files tools like tfenv consult when run from dir.
+func FindPinsUpward(dir string, tools []string) []Pin {
+	var pins []Pin
+	for dir = filepath.Clean(dir); ; dir = filepath.Dir(dir) {
+		pins = append(pins, readPins(dir, tools)...)
+		if filepath.Dir(dir) == dir {
+			return pins
+		}
+	}
+}
+
+// readPins reads the version files of the given tools in a single directory,
+// under each name in PinNames.
+func readPins(dir string, tools []string) []Pin {
+	var pins []Pin
+
+	// Map each name a tool is pinned under back to the tool
+	toolsByName := make(map[string]string)
+	for _, tool := range tools {
+		for _, name := range PinNames(tool) {
+			toolsByName[name] = tool
+
+			path := filepath.Join(dir, VersionFileName(name))
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if version, err := utils.NormalizeVersion(strings.TrimSpace(string(data))); err == nil {
+				pins = append(pins, Pin{Tool: tool, Version: version, File: path})
+			}
+		}
+	}
+
+	path := filepath.Join(dir, ToolVersionsFile)
+	f, err := os.Open(path)
+	if err != nil {
+		return pins
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		tool, ok := toolsByName[fields[0]]
+		if !ok {
+			continue
+		}
+		// Later fields are fallback versions, which are pinned as well
+		for _, field := range fields[1:] {
+			if version, err := utils.NormalizeVersion(field); err == nil {
+				pins = append(pins, Pin{Tool: tool, Version: version, File: path})
+			}
+		}
+	}
+
+	return pins
+}
+
+// DirSize returns the total size of the regular files under dir.
+func DirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// depth returns how many levels path is below root.
+func depth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
@@ -0,0 +1,122 @@
+package prune
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	versions := []string{"v1.9.0", "v1.5.7", "v1.10.0", "v1.6.0", "v1.4.0", "v1.7.0-rc1"}
+
+	tests := []struct {
+		name      string
+		keep      int
+		protected map[string]bool
+		want      []string
+	}{
+		{"keep 3", 3, nil, []string{"v1.4.0", "v1.5.7", "v1.6.0"}},
+		{"semver order, not string order", 1, nil, []string{"v1.4.0", "v1.5.7", "v1.6.0", "v1.7.0-rc1", "v1.9.0"}},
+		{"keep more than installed", 10, nil, nil},
+		{"protected versions are kept", 2, map[string]bool{"v1.4.0": true, "v1.6.0": true}, []string{"v1.5.7", "v1.7.0-rc1"}},
+		{"newest protected counts towards keep", 1, map[string]bool{"v1.10.0": true}, []string{"v1.4.0", "v1.5.7", "v1.6.0", "v1.7.0-rc1", "v1.9.0"}},
+		{"keep 0 keeps only protected", 0, map[string]bool{"v1.6.0": true}, []string{"v1.4.0", "v1.5.7", "v1.7.0-rc1", "v1.9.0", "v1.10.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Plan(versions, tt.keep, tt.protected); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Plan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func pinStrings(pins []Pin) []string {
+	var out []string
+	for _, pin := range pins {
+		out = append(out, pin.Tool+"@"+pin.Version)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestFindPins(t *testing.T) {
+	root := t.TempDir()
+	tools := []string{"terraform", "terragrunt"}
+
+	writeFile(t, filepath.Join(root, "infra", ".terraform-version"), "1.5.7\n")
+	writeFile(t, filepath.Join(root, "infra", "live", ".terragrunt-version"), "v0.54.0")
+	writeFile(t, filepath.Join(root, "a", "b", ".tool-versions"), "# pinned\nterraform 1.6.0 1.5.0\nnodejs 20.0.0\n")
+	writeFile(t, filepath.Join(root, "a", "b", "c", "d", ".terraform-version"), "1.1.0") // Too deep
+	writeFile(t, filepath.Join(root, ".git", ".terraform-version"), "1.2.0")             // Hidden
+	writeFile(t, filepath.Join(root, "other", ".terraform-version"), "latest:^1.5")      // Not a version
+	writeFile(t, filepath.Join(root, "other", ".tofu-version"), "1.6.0")                 // Not a listed tool
+
+	got := pinStrings(FindPins([]string{root, filepath.Join(root, "missing")}, tools))
+	want := []string{"terraform@v1.5.0", "terraform@v1.5.7", "terraform@v1.6.0", "terragrunt@v0.54.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPins() = %v, want %v", got, want)
+	}
+}
+
+func TestFindPinsUpward(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".terraform-version"), "1.4.0")
+	writeFile(t, filepath.Join(root, "project", ".terraform-version"), "1.6.0")
+	writeFile(t, filepath.Join(root, "project", "sub", "deeper", ".terraform-version"), "1.9.0") // Below dir
+
+	got := pinStrings(FindPinsUpward(filepath.Join(root, "project", "sub"), []string{"terraform"}))
+	want := []string{"terraform@v1.4.0", "terraform@v1.6.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPinsUpward() = %v, want %v", got, want)
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "terraform"), "12345")
+	writeFile(t, filepath.Join(dir, "docs", "README"), "abc")
+	if err := os.Symlink(filepath.Join(dir, "terraform"), filepath.Join(dir, "link")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() error = %v", err)
+	}
+	if size != 8 {
+		t.Errorf("DirSize() = %d, want 8", size)
+	}
+
+	if _, err := DirSize(filepath.Join(dir, "missing")); err == nil {
+		t.Error("DirSize() of a missing directory should fail")
+	}
+}
+
+func TestFindPins_OpenTofu(t *testing.T) {
+	root := t.TempDir()
+
+	// tofuenv and asdf call OpenTofu "opentofu"
+	writeFile(t, filepath.Join(root, "tofuenv", ".opentofu-version"), "1.6.2\n")
+	writeFile(t, filepath.Join(root, "asdf", ".tool-versions"), "opentofu 1.7.0\n")
+	writeFile(t, filepath.Join(root, "binarius", ".tofu-version"), "1.8.0")
+
+	got := pinStrings(FindPins([]string{root}, []string{"tofu"}))
+	want := []string{"tofu@v1.6.2", "tofu@v1.7.0", "tofu@v1.8.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPins() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package symlink
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 )
@@ -32,6 +33,8 @@ func (m *Manager) Create(source, target string) error {
 		return fmt.Errorf("failed to create symlink from %s to %s: %w", target, source, err)
 	}
 
+	slog.Info("Created symlink", "target", target, "source", source)
+
 	return nil
 }
 
@@ -61,6 +64,7 @@ func (m *Manager) Update(source, target string) error {
 	_ = os.Remove(tmpLinkPath) // Remove the file, we just need the name
 
 	// Create temporary symlink
+	slog.Debug("Creating temporary symlink", "path", tmpLinkPath, "source", source)
 	if err := os.Symlink(source, tmpLinkPath); err != nil {
 		return fmt.Errorf("failed to create temporary symlink: %w", err)
 	}
@@ -72,6 +76,7 @@ func (m *Manager) Update(source, target string) error {
 		return fmt.Errorf("failed to atomically update symlink: %w", err)
 	}
 
+	slog.Info("Updated symlink", "target", target, "source", source)
 	return nil
 }
 
@@ -84,6 +89,7 @@ func (m *Manager) Remove(target string) error {
 	// Check if symlink exists
 	if _, err := os.Lstat(target); os.IsNotExist(err) {
 		// Already removed, idempotent success
+		slog.Debug("Symlink already removed", "target", target)
 		return nil
 	}
 
@@ -92,6 +98,7 @@ func (m *Manager) Remove(target string) error {
 		return fmt.Errorf("failed to remove symlink %s: %w", target, err)
 	}
 
+	slog.Info("Removed symlink", "target", target)
 	return nil
 }
 
@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultVersionCacheTTL is how long cached version lists are used before
+// the release API is queried again.
+const DefaultVersionCacheTTL = time.Hour
+
+// VersionCache keeps the results of ListVersions on disk, one JSON file per
+// tool, so shell completion doesn't query release APIs on every keystroke.
+// Entries record the URL versions were listed from, so changing a mirror
+// takes effect immediately.
+type VersionCache struct {
+	Dir string        // Directory holding the <tool>.json files
+	TTL time.Duration // How long cached versions are used; zero means DefaultVersionCacheTTL
+}
+
+// cachedVersions is the on-disk format of a VersionCache entry.
+type cachedVersions struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Source    string    `json:"source,omitempty"` // Where the versions were listed from, e.g. a mirror URL
+	Versions  []string  `json:"versions"`
+}
+
+// versionSource is implemented by tools that list versions from a URL, so
+// cached versions from another source (such as a different mirror) are
+// treated as stale.
+type versionSource interface {
+	versionsURL() string
+}
+
+// sourceOf returns where a tool lists its versions from, or "" if unknown.
+func sourceOf(tool Tool) string {
+	if s, ok := tool.(versionSource); ok {
+		return s.versionsURL()
+	}
+	return ""
+}
+
+// ListVersions returns the tool's available versions, newest first, from the
+// cache if it is fresh and from tool.ListVersions otherwise. A failure to
+// write the cache isn't an error; the versions are returned regardless.
+func (c *VersionCache) ListVersions(tool Tool) ([]string, error) {
+	path := c.path(tool)
+	source := sourceOf(tool)
+
+	if versions, ok := c.read(path, source); ok {
+		slog.Debug("Using cached versions", "tool", tool.GetName(), "cache", path)
+		return versions, nil
+	}
+
+	versions, err := tool.ListVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.write(path, source, versions); err != nil {
+		slog.Debug("Failed to cache versions", "tool", tool.GetName(), "cache", path, "error", err)
+	}
+
+	return versions, nil
+}
+
+// read returns the cached versions at path if they exist, are fresh, and
+// were listed from source.
+func (c *VersionCache) read(path, source string) ([]string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached cachedVersions
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false
+	}
+	if cached.Source != source {
+		return nil, false
+	}
+
+	ttl := c.TTL
+	if ttl == 0 {
+		ttl = DefaultVersionCacheTTL
+	}
+	if age := time.Since(cached.FetchedAt); age < 0 || age > ttl {
+		return nil, false
+	}
+
+	return cached.Versions, true
+}
+
+// write stores versions at path, replacing the file atomically so concurrent
+// completions never read a partial file.
+func (c *VersionCache) write(path, source string, versions []string) error {
+	data, err := json.Marshal(cachedVersions{FetchedAt: time.Now(), Source: source, Versions: versions})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", c.Dir, err)
+	}
+
+	tmpFile, err := os.CreateTemp(c.Dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *VersionCache) path(tool Tool) string {
+	return filepath.Join(c.Dir, tool.GetName()+".json")
+}
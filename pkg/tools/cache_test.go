@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// countingTool counts calls to ListVersions.
+type countingTool struct {
+	mockTool
+	calls int
+	err   error
+}
+
+func (c *countingTool) ListVersions() ([]string, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.versions, nil
+}
+
+func TestVersionCache_ListVersions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "versions")
+	tool := &countingTool{mockTool: mockTool{name: "terraform", versions: []string{"v1.6.1", "v1.6.0"}}}
+	cache := &VersionCache{Dir: dir}
+
+	// The first call queries the tool and fills the cache
+	for i := 0; i < 2; i++ {
+		versions, err := cache.ListVersions(tool)
+		if err != nil {
+			t.Fatalf("ListVersions() error = %v", err)
+		}
+		if !reflect.DeepEqual(versions, tool.versions) {
+			t.Errorf("ListVersions() = %v, want %v", versions, tool.versions)
+		}
+	}
+	if tool.calls != 1 {
+		t.Errorf("tool.ListVersions() called %d times, want 1", tool.calls)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "terraform.json")); err != nil {
+		t.Errorf("cache file not written: %v", err)
+	}
+
+	// Stale entries are refreshed
+	stale := filepath.Join(dir, "terraform.json")
+	old := time.Now().Add(-2 * DefaultVersionCacheTTL)
+	data := []byte(`{"fetched_at":"` + old.Format(time.RFC3339) + `","versions":["v1.0.0"]}`)
+	if err := os.WriteFile(stale, data, 0644); err != nil {
+		t.Fatalf("failed to write stale cache: %v", err)
+	}
+	versions, err := cache.ListVersions(tool)
+	if err != nil {
+		t.Fatalf("ListVersions() error = %v", err)
+	}
+	if tool.calls != 2 || !reflect.DeepEqual(versions, tool.versions) {
+		t.Errorf("stale cache: ListVersions() = %v after %d calls, want %v after 2", versions, tool.calls, tool.versions)
+	}
+
+	// Corrupt entries are ignored
+	if err := os.WriteFile(stale, []byte("{"), 0644); err != nil {
+		t.Fatalf("failed to corrupt cache: %v", err)
+	}
+	if _, err := cache.ListVersions(tool); err != nil || tool.calls != 3 {
+		t.Errorf("corrupt cache: error = %v after %d calls, want nil after 3", err, tool.calls)
+	}
+}
+
+// sourcedTool lists versions from a URL that can change, like a mirrored tool.
+type sourcedTool struct {
+	countingTool
+	source string
+}
+
+func (s *sourcedTool) versionsURL() string { return s.source }
+
+func TestVersionCache_SourceChange(t *testing.T) {
+	dir := t.TempDir()
+	cache := &VersionCache{Dir: dir}
+	tool := &sourcedTool{
+		countingTool: countingTool{mockTool: mockTool{name: "terraform", versions: []string{"v1.6.0"}}},
+		source:       "https://releases.hashicorp.com/terraform/index.json",
+	}
+
+	if _, err := cache.ListVersions(tool); err != nil {
+		t.Fatalf("ListVersions() error = %v", err)
+	}
+
+	// A different mirror must not be served versions cached from the old source
+	tool.source = "https://mirror.example.com/terraform/index.json"
+	tool.versions = []string{"v1.6.1", "v1.6.0"}
+	versions, err := cache.ListVersions(tool)
+	if err != nil {
+		t.Fatalf("ListVersions() error = %v", err)
+	}
+	if tool.calls != 2 || !reflect.DeepEqual(versions, tool.versions) {
+		t.Errorf("after source change: ListVersions() = %v after %d calls, want %v after 2", versions, tool.calls, tool.versions)
+	}
+
+	// The new source is cached in turn
+	if _, err := cache.ListVersions(tool); err != nil || tool.calls != 2 {
+		t.Errorf("same source: error = %v after %d calls, want nil after 2", err, tool.calls)
+	}
+}
+
+func TestVersionCache_ListVersionsError(t *testing.T) {
+	dir := t.TempDir()
+	wantErr := errors.New("rate limited")
+	tool := &countingTool{mockTool: mockTool{name: "tofu"}, err: wantErr}
+
+	if _, err := (&VersionCache{Dir: dir}).ListVersions(tool); !errors.Is(err, wantErr) {
+		t.Errorf("ListVersions() error = %v, want %v", err, wantErr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tofu.json")); !os.IsNotExist(err) {
+		t.Error("failed lookups must not be cached")
+	}
+}
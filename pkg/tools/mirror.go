@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// Mirror holds the mirror base URLs that replace upstream hosts. Repository
+// managers such as Artifactory and Nexus proxy one upstream host per
+// repository, so each host can have its own base URL.
+type Mirror struct {
+	Base  string            // Base URL for every upstream host without an entry in Hosts
+	Hosts map[string]string // Base URL per upstream host, e.g. "github.com"
+}
+
+// IsZero reports whether no mirror is configured.
+func (m Mirror) IsZero() bool {
+	return m.Base == "" && len(m.Hosts) == 0
+}
+
+// baseFor returns the base URL that replaces an upstream host, or "" if the
+// host isn't mirrored.
+func (m Mirror) baseFor(host string) string {
+	if base := m.Hosts[host]; base != "" {
+		return base
+	}
+	return m.Base
+}
+
+// mirrorable is implemented by tools whose upstream endpoints can be redirected
+// to a mirror. It returns a copy of the tool that routes all traffic through mirror.
+type mirrorable interface {
+	withMirror(mirror Mirror) Tool
+}
+
+// WithMirror returns a copy of tool whose download, checksum, and version-listing
+// URLs are rewritten to go through the mirror.
+// If no mirror is configured, or the tool doesn't support mirrors, the tool is returned unchanged.
+func WithMirror(tool Tool, mirror Mirror) Tool {
+	if mirror.IsZero() {
+		return tool
+	}
+
+	if m, ok := tool.(mirrorable); ok {
+		return m.withMirror(mirror)
+	}
+
+	return tool
+}
+
+// ValidateMirror checks that a mirror base URL is an absolute HTTP(S) URL.
+func ValidateMirror(mirror string) error {
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return fmt.Errorf("invalid mirror URL %q: %w", mirror, err)
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("invalid mirror URL %q: scheme must be https or http", mirror)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid mirror URL %q: missing host", mirror)
+	}
+
+	return nil
+}
+
+// MirrorURL rewrites an upstream URL to go through a mirror.
+// The scheme and host of the upstream URL are replaced by those of the base URL
+// for the upstream host (or mirror.Base if the host has none), and the upstream
+// path is appended to the base path. Query strings are preserved.
+//
+// Example:
+//
+//	MirrorURL("https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_SHA256SUMS",
+//	          Mirror{Hosts: map[string]string{"releases.hashicorp.com": "https://artifactory.example.com/hashicorp"}})
+//	=> "https://artifactory.example.com/hashicorp/terraform/1.6.0/terraform_1.6.0_SHA256SUMS"
+//
+// If the host isn't mirrored or either URL cannot be parsed, the upstream URL is returned unchanged.
+func MirrorURL(upstream string, mirror Mirror) string {
+	if mirror.IsZero() {
+		return upstream
+	}
+
+	up, err := url.Parse(upstream)
+	if err != nil {
+		return upstream
+	}
+
+	baseURL := mirror.baseFor(up.Host)
+	if baseURL == "" {
+		return upstream
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return upstream
+	}
+
+	rewritten := *base
+	rewritten.Path = strings.TrimSuffix(base.Path, "/") + up.Path
+	rewritten.RawPath = ""
+	rewritten.RawQuery = up.RawQuery
+
+	slog.Debug("Routing request through mirror", "upstream", upstream, "mirror", rewritten.Redacted())
+	return rewritten.String()
+}
@@ -0,0 +1,152 @@
+package tools
+
+import "testing"
+
+// TestMirrorURL verifies upstream URLs are rewritten onto the mirror base URL.
+func TestMirrorURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream string
+		mirror   Mirror
+		want     string
+	}{
+		{
+			name:     "no mirror",
+			upstream: "https://releases.hashicorp.com/terraform/index.json",
+			mirror:   Mirror{},
+			want:     "https://releases.hashicorp.com/terraform/index.json",
+		},
+		{
+			name:     "mirror with base path",
+			upstream: "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_SHA256SUMS",
+			mirror:   Mirror{Base: "https://artifactory.example.com/artifactory/hashicorp"},
+			want:     "https://artifactory.example.com/artifactory/hashicorp/terraform/1.6.0/terraform_1.6.0_SHA256SUMS",
+		},
+		{
+			name:     "mirror with trailing slash",
+			upstream: "https://releases.hashicorp.com/terraform/index.json",
+			mirror:   Mirror{Base: "https://artifactory.example.com/hashicorp/"},
+			want:     "https://artifactory.example.com/hashicorp/terraform/index.json",
+		},
+		{
+			name:     "query string is preserved",
+			upstream: "https://api.github.com/repos/opentofu/opentofu/releases?per_page=100",
+			mirror:   Mirror{Base: "http://mirror.internal:8081/github"},
+			want:     "http://mirror.internal:8081/github/repos/opentofu/opentofu/releases?per_page=100",
+		},
+		{
+			name:     "host mirror",
+			upstream: "https://api.github.com/repos/opentofu/opentofu/releases?per_page=100",
+			mirror: Mirror{
+				Base:  "https://artifactory.example.com/hashicorp",
+				Hosts: map[string]string{"api.github.com": "https://artifactory.example.com/github-api"},
+			},
+			want: "https://artifactory.example.com/github-api/repos/opentofu/opentofu/releases?per_page=100",
+		},
+		{
+			name:     "other hosts fall back to base",
+			upstream: "https://github.com/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_SHA256SUMS",
+			mirror: Mirror{
+				Base:  "https://artifactory.example.com/github",
+				Hosts: map[string]string{"api.github.com": "https://artifactory.example.com/github-api"},
+			},
+			want: "https://artifactory.example.com/github/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_SHA256SUMS",
+		},
+		{
+			name:     "unmirrored host without base",
+			upstream: "https://github.com/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_SHA256SUMS",
+			mirror:   Mirror{Hosts: map[string]string{"api.github.com": "https://artifactory.example.com/github-api"}},
+			want:     "https://github.com/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_SHA256SUMS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MirrorURL(tt.upstream, tt.mirror)
+			if got != tt.want {
+				t.Errorf("MirrorURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidateMirror verifies mirror URL validation.
+func TestValidateMirror(t *testing.T) {
+	tests := []struct {
+		name    string
+		mirror  string
+		wantErr bool
+	}{
+		{name: "https mirror", mirror: "https://mirror.example.com/hashicorp", wantErr: false},
+		{name: "http mirror", mirror: "http://mirror.internal:8081", wantErr: false},
+		{name: "missing scheme", mirror: "mirror.example.com", wantErr: true},
+		{name: "unsupported scheme", mirror: "ftp://mirror.example.com", wantErr: true},
+		{name: "missing host", mirror: "https://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMirror(tt.mirror)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMirror(%q) error = %v, wantErr %v", tt.mirror, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestWithMirror verifies that mirrored tools rewrite their URLs without
+// modifying the registered tool.
+func TestWithMirror(t *testing.T) {
+	mirror := Mirror{Base: "https://mirror.example.com/base"}
+
+	tests := []struct {
+		name         string
+		tool         Tool
+		wantDownload string
+		wantChecksum string
+	}{
+		{
+			name:         "terraform",
+			tool:         &Terraform{Name: "terraform"},
+			wantDownload: "https://mirror.example.com/base/terraform/1.6.0/terraform_1.6.0_linux_amd64.zip",
+			wantChecksum: "https://mirror.example.com/base/terraform/1.6.0/terraform_1.6.0_SHA256SUMS",
+		},
+		{
+			name:         "tofu",
+			tool:         &OpenTofu{Name: "tofu"},
+			wantDownload: "https://mirror.example.com/base/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_linux_amd64.zip",
+			wantChecksum: "https://mirror.example.com/base/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_SHA256SUMS",
+		},
+		{
+			name:         "terragrunt",
+			tool:         &Terragrunt{Name: "terragrunt"},
+			wantDownload: "https://mirror.example.com/base/gruntwork-io/terragrunt/releases/download/v1.6.0/terragrunt_linux_amd64",
+			wantChecksum: "https://mirror.example.com/base/gruntwork-io/terragrunt/releases/download/v1.6.0/SHA256SUMS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.tool.GetDownloadURL("v1.6.0", "linux", "amd64")
+			mirrored := WithMirror(tt.tool, mirror)
+
+			if got := mirrored.GetDownloadURL("v1.6.0", "linux", "amd64"); got != tt.wantDownload {
+				t.Errorf("GetDownloadURL() = %q, want %q", got, tt.wantDownload)
+			}
+			if got := mirrored.GetChecksumURL("v1.6.0", "linux", "amd64"); got != tt.wantChecksum {
+				t.Errorf("GetChecksumURL() = %q, want %q", got, tt.wantChecksum)
+			}
+
+			// The original tool must be unaffected
+			if got := tt.tool.GetDownloadURL("v1.6.0", "linux", "amd64"); got != original {
+				t.Errorf("original GetDownloadURL() changed to %q, want %q", got, original)
+			}
+		})
+	}
+
+	// Empty mirror returns the tool unchanged
+	tf := &Terraform{Name: "terraform"}
+	if WithMirror(tf, Mirror{}) != Tool(tf) {
+		t.Error("WithMirror() with empty mirror should return the same tool")
+	}
+}
@@ -9,7 +9,7 @@ import (
 // Each tool (terraform, tofu, terragrunt) will have a concrete implementation of this interface.
 //
 // All methods must be safe for concurrent use (read-only operations).
-// All URLs returned must use HTTPS scheme only.
+// All URLs returned must use HTTPS scheme only, unless rewritten by a configured mirror (see WithMirror).
 // Version strings must follow semantic versioning (vX.Y.Z format).
 type Tool interface {
 	// GetName returns the unique identifier for this tool (e.g., "terraform", "tofu").
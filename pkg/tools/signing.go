@@ -0,0 +1,57 @@
+package tools
+
+import (
+	_ "embed" // for the bundled signing keys
+)
+
+// hashicorpKey is HashiCorp's release signing key (fingerprint C874 011F 0AB4 0511 0D02 1055 3436 5D94 72D7 468F),
+// published at https://www.hashicorp.com/security.
+//
+//go:embed keys/hashicorp.asc
+var hashicorpKey []byte
+
+// sigstoreRoots is the Sigstore public-good Fulcio CA bundle used to verify keyless cosign signatures.
+//
+//go:embed keys/sigstore-fulcio.pem
+var sigstoreRoots []byte
+
+// sigstoreRekorKeys is the Sigstore public-good Rekor transparency log key, which
+// signs the entry timestamps of keyless cosign signatures.
+//
+//go:embed keys/sigstore-rekor.pem
+var sigstoreRekorKeys []byte
+
+// sigstoreRekorURL is the Sigstore public-good Rekor transparency log.
+const sigstoreRekorURL = "https://rekor.sigstore.dev"
+
+// GPGSigned is implemented by tools whose checksum files are signed with a
+// detached OpenPGP signature. Tools that don't implement it are verified by
+// checksum only.
+type GPGSigned interface {
+	// GetChecksumSignatureURL returns the URL of the detached signature of the checksum file.
+	GetChecksumSignatureURL(version, os, arch string) string
+
+	// SigningKeys returns the ASCII-armored public keys trusted to sign checksum files.
+	SigningKeys() [][]byte
+}
+
+// CosignSigned is implemented by tools whose checksum files are signed with
+// keyless cosign (Sigstore). Bundles are verified offline against bundled trust
+// roots; detached signatures also need their transparency log entry from Rekor.
+type CosignSigned interface {
+	// GetChecksumCosignBundleURL returns the URL of the cosign bundle of the checksum file.
+	GetChecksumCosignBundleURL(version, os, arch string) string
+
+	// GetChecksumCosignURLs returns the URLs of the signature and signing certificate of the checksum file.
+	GetChecksumCosignURLs(version, os, arch string) (signatureURL, certificateURL string)
+
+	// CosignIdentity returns the required certificate subject prefix (URI SAN) and OIDC issuer.
+	CosignIdentity() (subjectPrefix, issuer string)
+
+	// CosignTrustRoots returns the PEM bundle of certificate authorities trusted to issue signing certificates.
+	CosignTrustRoots() []byte
+
+	// CosignTransparencyLog returns the URL of the Rekor log that records the
+	// signatures, and the PEM public keys trusted to sign its entry timestamps.
+	CosignTransparencyLog() (url string, keys []byte)
+}
@@ -3,15 +3,19 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/nixknight/binarius/pkg/httpclient"
 )
 
 // Terraform implements the Tool interface for HashiCorp Terraform.
 type Terraform struct {
-	Name string
+	Name   string
+	Mirror Mirror // Optional mirror replacing https://releases.hashicorp.com
 }
 
 // GetName returns the tool name.
@@ -25,10 +29,10 @@ func (t *Terraform) GetDownloadURL(version, os, arch string) string {
 	// Remove 'v' prefix if present for consistency with HashiCorp URLs
 	version = strings.TrimPrefix(version, "v")
 
-	return fmt.Sprintf(
+	return MirrorURL(fmt.Sprintf(
 		"https://releases.hashicorp.com/terraform/%s/terraform_%s_%s_%s.zip",
 		version, version, os, arch,
-	)
+	), t.Mirror)
 }
 
 // GetChecksumURL returns the URL for the SHA256SUMS file for a specific version.
@@ -37,22 +41,43 @@ func (t *Terraform) GetChecksumURL(version, os, arch string) string {
 	// Remove 'v' prefix if present
 	version = strings.TrimPrefix(version, "v")
 
-	return fmt.Sprintf(
+	return MirrorURL(fmt.Sprintf(
 		"https://releases.hashicorp.com/terraform/%s/terraform_%s_SHA256SUMS",
 		version, version,
-	)
+	), t.Mirror)
+}
+
+// GetChecksumSignatureURL returns the URL of the detached GPG signature of the SHA256SUMS file.
+// HashiCorp publishes it at: https://releases.hashicorp.com/terraform/{version}/terraform_{version}_SHA256SUMS.sig
+func (t *Terraform) GetChecksumSignatureURL(version, os, arch string) string {
+	return t.GetChecksumURL(version, os, arch) + ".sig"
+}
+
+// SigningKeys returns HashiCorp's release signing key.
+func (t *Terraform) SigningKeys() [][]byte {
+	return [][]byte{hashicorpKey}
+}
+
+// VerificationMethods returns the checks applied to terraform releases:
+// a GPG-signed SHA256SUMS file.
+func (t *Terraform) VerificationMethods() []string {
+	return []string{VerifyGPG, VerifySHA256}
+}
+
+// versionsURL returns the URL of HashiCorp's release index for terraform.
+func (t *Terraform) versionsURL() string {
+	return MirrorURL("https://releases.hashicorp.com/terraform/index.json", t.Mirror)
 }
 
 // ListVersions fetches all available terraform versions from HashiCorp's releases API.
 // Returns versions in descending order (newest first).
 func (t *Terraform) ListVersions() ([]string, error) {
-	// HashiCorp releases API endpoint
-	indexURL := "https://releases.hashicorp.com/terraform/index.json"
+	indexURL := t.versionsURL()
+
+	slog.Debug("Fetching available versions", "tool", t.Name, "url", indexURL)
 
 	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := httpclient.New(30 * time.Second)
 
 	// Fetch the index
 	resp, err := client.Get(indexURL)
@@ -62,7 +87,7 @@ func (t *Terraform) ListVersions() ([]string, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch terraform versions: HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch terraform versions: %w", httpclient.NewStatusError(resp))
 	}
 
 	// Parse the JSON response
@@ -89,9 +114,17 @@ func (t *Terraform) ListVersions() ([]string, error) {
 		return compareVersions(versions[i], versions[j]) > 0
 	})
 
+	slog.Debug("Fetched available versions", "tool", t.Name, "count", len(versions))
 	return versions, nil
 }
 
+// withMirror returns a copy of the terraform tool that routes requests through mirror.
+func (t *Terraform) withMirror(mirror Mirror) Tool {
+	mirrored := *t
+	mirrored.Mirror = mirror
+	return &mirrored
+}
+
 // GetBinaryName returns the name of the terraform binary.
 func (t *Terraform) GetBinaryName() string {
 	return "terraform"
@@ -219,6 +219,28 @@ func TestTerraformInterface(t *testing.T) {
 	var _ Tool = (*Terraform)(nil)
 }
 
+// TestTerraformGPGSigned verifies the checksum signature URL and bundled signing key.
+func TestTerraformGPGSigned(t *testing.T) {
+	var _ GPGSigned = (*Terraform)(nil)
+
+	tf := &Terraform{Name: "terraform"}
+	want := "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_SHA256SUMS.sig"
+	if got := tf.GetChecksumSignatureURL("v1.6.0", "linux", "amd64"); got != want {
+		t.Errorf("GetChecksumSignatureURL() = %q, want %q", got, want)
+	}
+
+	mirrored := WithMirror(tf, Mirror{Base: "https://mirror.example.com/hashicorp"}).(*Terraform)
+	want = "https://mirror.example.com/hashicorp/terraform/1.6.0/terraform_1.6.0_SHA256SUMS.sig"
+	if got := mirrored.GetChecksumSignatureURL("1.6.0", "linux", "amd64"); got != want {
+		t.Errorf("GetChecksumSignatureURL() with mirror = %q, want %q", got, want)
+	}
+
+	keys := tf.SigningKeys()
+	if len(keys) != 1 || !strings.Contains(string(keys[0]), "BEGIN PGP PUBLIC KEY BLOCK") {
+		t.Errorf("SigningKeys() should return the armored HashiCorp key")
+	}
+}
+
 // TestTerraformRegistration verifies terraform can be registered.
 func TestTerraformRegistration(t *testing.T) {
 	// Clear registry for clean test
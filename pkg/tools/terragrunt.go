@@ -3,15 +3,19 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/nixknight/binarius/pkg/httpclient"
 )
 
 // Terragrunt implements the Tool interface for Terragrunt.
 type Terragrunt struct {
-	Name string
+	Name   string
+	Mirror Mirror // Optional mirror replacing https://github.com and https://api.github.com
 }
 
 // GetName returns the tool name.
@@ -28,10 +32,10 @@ func (t *Terragrunt) GetDownloadURL(version, os, arch string) string {
 		version = "v" + version
 	}
 
-	return fmt.Sprintf(
+	return MirrorURL(fmt.Sprintf(
 		"https://github.com/gruntwork-io/terragrunt/releases/download/%s/terragrunt_%s_%s",
 		version, os, arch,
-	)
+	), t.Mirror)
 }
 
 // GetChecksumURL returns the URL for the SHA256SUMS file for a specific version.
@@ -42,23 +46,27 @@ func (t *Terragrunt) GetChecksumURL(version, os, arch string) string {
 		version = "v" + version
 	}
 
-	return fmt.Sprintf(
+	return MirrorURL(fmt.Sprintf(
 		"https://github.com/gruntwork-io/terragrunt/releases/download/%s/SHA256SUMS",
 		version,
-	)
+	), t.Mirror)
+}
+
+// versionsURL returns the URL of the GitHub releases API for terragrunt.
+func (t *Terragrunt) versionsURL() string {
+	return MirrorURL("https://api.github.com/repos/gruntwork-io/terragrunt/releases?per_page=100", t.Mirror)
 }
 
 // ListVersions fetches all available terragrunt versions from GitHub releases.
 // Filters out alpha versions (alpha-*, v-alpha-*).
 // Returns versions in descending order (newest first).
 func (t *Terragrunt) ListVersions() ([]string, error) {
-	// GitHub releases API endpoint
-	apiURL := "https://api.github.com/repos/gruntwork-io/terragrunt/releases?per_page=100"
+	apiURL := t.versionsURL()
+
+	slog.Debug("Fetching available versions", "tool", t.Name, "url", apiURL)
 
 	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := httpclient.New(30 * time.Second)
 
 	// Create request with User-Agent (required by GitHub)
 	req, err := http.NewRequest("GET", apiURL, nil)
@@ -75,7 +83,7 @@ func (t *Terragrunt) ListVersions() ([]string, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch terragrunt versions: HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch terragrunt versions: %w", httpclient.NewStatusError(resp))
 	}
 
 	// Parse the JSON response
@@ -117,9 +125,17 @@ func (t *Terragrunt) ListVersions() ([]string, error) {
 		return compareTerragruntVersions(versions[i], versions[j]) > 0
 	})
 
+	slog.Debug("Fetched available versions", "tool", t.Name, "count", len(versions))
 	return versions, nil
 }
 
+// withMirror returns a copy of the terragrunt tool that routes requests through mirror.
+func (t *Terragrunt) withMirror(mirror Mirror) Tool {
+	mirrored := *t
+	mirrored.Mirror = mirror
+	return &mirrored
+}
+
 // GetBinaryName returns the name of the terragrunt binary.
 func (t *Terragrunt) GetBinaryName() string {
 	return "terragrunt"
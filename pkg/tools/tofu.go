@@ -3,16 +3,20 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/nixknight/binarius/pkg/httpclient"
 )
 
 // OpenTofu implements the Tool interface for OpenTofu.
 // OpenTofu is an open-source fork of Terraform maintained by the Linux Foundation.
 type OpenTofu struct {
-	Name string
+	Name   string
+	Mirror Mirror // Optional mirror replacing https://github.com and https://api.github.com
 }
 
 // GetName returns the tool name.
@@ -35,10 +39,10 @@ func (o *OpenTofu) GetDownloadURL(version, os, arch string) string {
 	// Remove 'v' prefix for the filename part
 	versionNum := strings.TrimPrefix(version, "v")
 
-	return fmt.Sprintf(
+	return MirrorURL(fmt.Sprintf(
 		"https://github.com/opentofu/opentofu/releases/download/%s/tofu_%s_%s_%s.zip",
 		versionTag, versionNum, os, arch,
-	)
+	), o.Mirror)
 }
 
 // GetChecksumURL returns the URL for the SHA256SUMS file for a specific version.
@@ -54,10 +58,46 @@ func (o *OpenTofu) GetChecksumURL(version, os, arch string) string {
 	// Remove 'v' prefix for the filename part
 	versionNum := strings.TrimPrefix(version, "v")
 
-	return fmt.Sprintf(
+	return MirrorURL(fmt.Sprintf(
 		"https://github.com/opentofu/opentofu/releases/download/%s/tofu_%s_SHA256SUMS",
 		versionTag, versionNum,
-	)
+	), o.Mirror)
+}
+
+// GetChecksumCosignBundleURL returns the URL of the Sigstore bundle of the SHA256SUMS file,
+// tofu_{version}_SHA256SUMS.sigstore.json. Releases without one are verified with
+// the detached signature and certificate instead.
+func (o *OpenTofu) GetChecksumCosignBundleURL(version, os, arch string) string {
+	return o.GetChecksumURL(version, os, arch) + ".sigstore.json"
+}
+
+// GetChecksumCosignURLs returns the URLs of the cosign signature and certificate of the SHA256SUMS file.
+// OpenTofu publishes them next to the checksums as tofu_{version}_SHA256SUMS.sig and .pem.
+func (o *OpenTofu) GetChecksumCosignURLs(version, os, arch string) (string, string) {
+	checksumURL := o.GetChecksumURL(version, os, arch)
+	return checksumURL + ".sig", checksumURL + ".pem"
+}
+
+// CosignIdentity returns the identity of OpenTofu's release workflow on GitHub Actions.
+func (o *OpenTofu) CosignIdentity() (string, string) {
+	return "https://github.com/opentofu/opentofu/.github/workflows/", "https://token.actions.githubusercontent.com"
+}
+
+// CosignTrustRoots returns the Sigstore public-good Fulcio certificate authorities.
+func (o *OpenTofu) CosignTrustRoots() []byte {
+	return sigstoreRoots
+}
+
+// CosignTransparencyLog returns the Sigstore public-good Rekor log, routed
+// through the mirror if one is configured.
+func (o *OpenTofu) CosignTransparencyLog() (string, []byte) {
+	return MirrorURL(sigstoreRekorURL, o.Mirror), sigstoreRekorKeys
+}
+
+// VerificationMethods returns the checks applied to OpenTofu releases:
+// a cosign-signed SHA256SUMS file.
+func (o *OpenTofu) VerificationMethods() []string {
+	return []string{VerifyCosign, VerifySHA256}
 }
 
 // githubRelease represents a GitHub release API response.
@@ -67,16 +107,20 @@ type githubRelease struct {
 	PreRelease bool   `json:"prerelease"`
 }
 
+// versionsURL returns the URL of the GitHub releases API for OpenTofu.
+func (o *OpenTofu) versionsURL() string {
+	return MirrorURL("https://api.github.com/repos/opentofu/opentofu/releases?per_page=100", o.Mirror)
+}
+
 // ListVersions fetches all available OpenTofu versions from GitHub releases API.
 // Returns versions in descending order (newest first).
 func (o *OpenTofu) ListVersions() ([]string, error) {
-	// GitHub API endpoint for OpenTofu releases
-	apiURL := "https://api.github.com/repos/opentofu/opentofu/releases?per_page=100"
+	apiURL := o.versionsURL()
+
+	slog.Debug("Fetching available versions", "tool", o.Name, "url", apiURL)
 
 	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := httpclient.New(30 * time.Second)
 
 	// Fetch releases
 	req, err := http.NewRequest("GET", apiURL, nil)
@@ -94,7 +138,7 @@ func (o *OpenTofu) ListVersions() ([]string, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch OpenTofu versions: HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch OpenTofu versions: %w", httpclient.NewStatusError(resp))
 	}
 
 	// Parse the JSON response
@@ -127,9 +171,17 @@ func (o *OpenTofu) ListVersions() ([]string, error) {
 		return compareTofuVersions(versions[i], versions[j]) > 0
 	})
 
+	slog.Debug("Fetched available versions", "tool", o.Name, "count", len(versions))
 	return versions, nil
 }
 
+// withMirror returns a copy of the OpenTofu tool that routes requests through mirror.
+func (o *OpenTofu) withMirror(mirror Mirror) Tool {
+	mirrored := *o
+	mirrored.Mirror = mirror
+	return &mirrored
+}
+
 // GetBinaryName returns the name of the OpenTofu binary.
 func (o *OpenTofu) GetBinaryName() string {
 	return "tofu"
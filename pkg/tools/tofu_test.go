@@ -252,6 +252,41 @@ func TestOpenTofuInterface(t *testing.T) {
 	var _ Tool = (*OpenTofu)(nil)
 }
 
+// TestOpenTofuCosignSigned verifies the cosign material URLs, identity, and bundled trust roots and log keys.
+func TestOpenTofuCosignSigned(t *testing.T) {
+	var _ CosignSigned = (*OpenTofu)(nil)
+
+	tofu := &OpenTofu{Name: "tofu"}
+	sigURL, certURL := tofu.GetChecksumCosignURLs("1.6.0", "linux", "amd64")
+	base := "https://github.com/opentofu/opentofu/releases/download/v1.6.0/tofu_1.6.0_SHA256SUMS"
+	if sigURL != base+".sig" || certURL != base+".pem" {
+		t.Errorf("GetChecksumCosignURLs() = %q, %q, want %q, %q", sigURL, certURL, base+".sig", base+".pem")
+	}
+	if bundleURL := tofu.GetChecksumCosignBundleURL("1.6.0", "linux", "amd64"); bundleURL != base+".sigstore.json" {
+		t.Errorf("GetChecksumCosignBundleURL() = %q, want %q", bundleURL, base+".sigstore.json")
+	}
+
+	subject, issuer := tofu.CosignIdentity()
+	if !strings.HasPrefix(subject, "https://github.com/opentofu/opentofu/") {
+		t.Errorf("CosignIdentity() subject = %q, want the opentofu/opentofu repository", subject)
+	}
+	if issuer != "https://token.actions.githubusercontent.com" {
+		t.Errorf("CosignIdentity() issuer = %q, want GitHub Actions", issuer)
+	}
+
+	if n := strings.Count(string(tofu.CosignTrustRoots()), "BEGIN CERTIFICATE"); n < 2 {
+		t.Errorf("CosignTrustRoots() contains %d certificates, want at least 2", n)
+	}
+
+	rekorURL, rekorKeys := tofu.CosignTransparencyLog()
+	if rekorURL != "https://rekor.sigstore.dev" {
+		t.Errorf("CosignTransparencyLog() url = %q, want the Sigstore public-good log", rekorURL)
+	}
+	if !strings.Contains(string(rekorKeys), "BEGIN PUBLIC KEY") {
+		t.Error("CosignTransparencyLog() returned no log keys")
+	}
+}
+
 // TestOpenTofuRegistration verifies OpenTofu can be registered.
 func TestOpenTofuRegistration(t *testing.T) {
 	// Clear registry for clean test
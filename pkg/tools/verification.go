@@ -0,0 +1,25 @@
+package tools
+
+// Verification methods a tool can declare for its releases.
+const (
+	VerifySHA256 = "sha256" // Checksum listed in a SHA256SUMS file
+	VerifySHA512 = "sha512" // Checksum listed in a SHA512SUMS file
+	VerifyGPG    = "gpg"    // Detached OpenPGP signature of the checksum file (see GPGSigned)
+	VerifyCosign = "cosign" // Keyless cosign signature of the checksum file (see CosignSigned)
+)
+
+// Verifiable is implemented by tools that declare which verification methods
+// apply to their releases.
+type Verifiable interface {
+	// VerificationMethods returns the applicable Verify* methods.
+	VerificationMethods() []string
+}
+
+// VerificationMethods returns the verification methods declared by tool.
+// Tools that don't implement Verifiable are verified by SHA256 checksum only.
+func VerificationMethods(tool Tool) []string {
+	if v, ok := tool.(Verifiable); ok {
+		return v.VerificationMethods()
+	}
+	return []string{VerifySHA256}
+}
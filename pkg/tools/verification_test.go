@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestVerificationMethods verifies the verification methods declared by each tool.
+func TestVerificationMethods(t *testing.T) {
+	tests := []struct {
+		name string
+		tool Tool
+		want []string
+	}{
+		{name: "terraform", tool: &Terraform{Name: "terraform"}, want: []string{VerifyGPG, VerifySHA256}},
+		{name: "tofu", tool: &OpenTofu{Name: "tofu"}, want: []string{VerifyCosign, VerifySHA256}},
+		{name: "terragrunt defaults to sha256", tool: &Terragrunt{Name: "terragrunt"}, want: []string{VerifySHA256}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerificationMethods(tt.tool); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationMethods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}